@@ -0,0 +1,38 @@
+// Package approach configures how weapons fare when they attack the sides and
+// rear of their targets.
+package approach
+
+import "github.com/griffithsh/squads/game/item"
+
+// Modifier is how attacking from the side or rear of a target affects an
+// attack.
+type Modifier struct {
+	// Damage multiplies the damage of the attack.
+	Damage float64
+
+	// ChanceToHit improves the chance to hit of the attack in the same way
+	// as skill.Description's AttackChanceToHitModifier.
+	ChanceToHit float64
+}
+
+// Modifiers are the Modifiers of attacks on the Side and Rear of a target.
+// Attacks from the front are never modified.
+type Modifiers struct {
+	Side, Rear Modifier
+}
+
+// Table configures the Modifiers that each class of weapon receives.
+type Table struct {
+	Classes map[item.Class]Modifiers
+
+	// Default is used for classes that are not present in Classes.
+	Default Modifiers
+}
+
+// For returns the Modifiers of attacks made with weapons of class.
+func (t Table) For(class item.Class) Modifiers {
+	if m, ok := t.Classes[class]; ok {
+		return m
+	}
+	return t.Default
+}
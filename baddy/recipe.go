@@ -6,6 +6,8 @@ import (
 	"github.com/griffithsh/squads/game"
 )
 
+//go:generate stringer -type=RecipeID
+
 // RecipeID identifies a baddy recipe.
 type RecipeID int
 
@@ -68,3 +70,13 @@ var Recipes map[RecipeID]Recipe = map[RecipeID]Recipe{
 		Skin:         "pale",
 	},
 }
+
+// ForProfession finds the Recipe of baddies of a profession.
+func ForProfession(profession string) (RecipeID, bool) {
+	for id, recipe := range Recipes {
+		if recipe.Profession == profession {
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -12,10 +12,13 @@ import (
 	"os"
 	"strings"
 
+	"github.com/griffithsh/squads/approach"
+	"github.com/griffithsh/squads/difficulty"
 	"github.com/griffithsh/squads/embedded"
 	"github.com/griffithsh/squads/game"
 	"github.com/griffithsh/squads/game/overworld/hbg"
 	"github.com/griffithsh/squads/game/overworld/procedural"
+	"github.com/griffithsh/squads/loot"
 	"github.com/griffithsh/squads/skill"
 )
 
@@ -31,6 +34,9 @@ type Archive struct {
 	images                 map[string]image.Image
 	overworldBaseTiles     map[procedural.Code]hbg.BaseTile
 	overworldEncroachments hbg.EncroachmentsCollection
+	lootTables             map[string]loot.Table
+	difficulty             difficulty.Model
+	approachModifiers      approach.Table
 }
 
 // NewArchive constructs a new Archive.
@@ -43,6 +49,7 @@ func NewArchive() (*Archive, error) {
 		images:                 map[string]image.Image{},
 		overworldBaseTiles:     map[procedural.Code]hbg.BaseTile{},
 		overworldEncroachments: hbg.EncroachmentsCollection{},
+		lootTables:             map[string]loot.Table{},
 	}
 	for _, sd := range internalSkills {
 		archive.skills[sd.ID] = sd
@@ -208,6 +215,42 @@ func (a *Archive) interpret(filename string, r io.Reader) error {
 		}
 		a.overworldEncroachments.Put(v)
 
+	case ".loot.json":
+		dec := json.NewDecoder(r)
+		var v loot.Table
+		err := dec.Decode(&v)
+		if err != nil {
+			return fmt.Errorf("parse %s: %v", filename, err)
+		}
+		if v.Baddy == "" {
+			return fmt.Errorf("configuration error: no baddy")
+		}
+		a.lootTables[v.Baddy] = v
+
+	case ".difficulty.json":
+		dec := json.NewDecoder(r)
+		var v difficulty.Model
+		err := dec.Decode(&v)
+		if err != nil {
+			return fmt.Errorf("parse %s: %v", filename, err)
+		}
+		if len(v.Tiers) == 0 {
+			return fmt.Errorf("configuration error: no tiers")
+		}
+		a.difficulty = v
+
+	case ".approach.json":
+		dec := json.NewDecoder(r)
+		var v approach.Table
+		err := dec.Decode(&v)
+		if err != nil {
+			return fmt.Errorf("parse %s: %v", filename, err)
+		}
+		if v.Default.Side.Damage == 0 || v.Default.Rear.Damage == 0 {
+			return fmt.Errorf("configuration error: no default modifiers")
+		}
+		a.approachModifiers = v
+
 	case ".appearance":
 		dec := json.NewDecoder(r)
 		var v struct {
@@ -261,6 +304,24 @@ func (a *Archive) GetImage(name string) (val image.Image, ok bool) {
 	return val, ok
 }
 
+// LootTable returns the loot Table for baddies constructed from the named
+// recipe.
+func (a *Archive) LootTable(baddy string) (loot.Table, bool) {
+	t, ok := a.lootTables[baddy]
+	return t, ok
+}
+
+// Difficulty returns the Model that scales opponents by level.
+func (a *Archive) Difficulty() difficulty.Model {
+	return a.difficulty
+}
+
+// ApproachModifiers returns the Table of how weapons fare when they attack the
+// sides and rear of their targets.
+func (a *Archive) ApproachModifiers() approach.Table {
+	return a.approachModifiers
+}
+
 func (a *Archive) GetOverworldBaseTiles() map[procedural.Code]hbg.BaseTile {
 	return a.overworldBaseTiles
 }
@@ -4,18 +4,25 @@ import (
 	"math/rand"
 
 	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/combat/arena"
+	"github.com/griffithsh/squads/game/overworld/procedural"
 )
 
 var internalCombatMaps = []game.CombatMapRecipe{}
 
-// GetCombatMap for use in a combat.
-func (a *Archive) GetCombatMap() *game.CombatMapRecipe {
-	switch len(a.combatMaps) {
-	case 0:
-		panic("no combat maps available")
-	case 1:
-		return &a.combatMaps[0]
-	default:
-		return &a.combatMaps[rand.Intn(len(a.combatMaps))]
+// GetCombatMap for use in a combat on the overworld terrain code between the
+// given number of teams. Hand-made maps from .terrain files are sometimes
+// chosen, otherwise a map is generated from seed.
+func (a *Archive) GetCombatMap(seed int64, code procedural.Code, teams int) *game.CombatMapRecipe {
+	prng := rand.New(rand.NewSource(seed))
+	var handmade []*game.CombatMapRecipe
+	for i := range a.combatMaps {
+		if len(a.combatMaps[i].Starts) >= teams {
+			handmade = append(handmade, &a.combatMaps[i])
+		}
 	}
+	if i := prng.Intn(len(handmade) + 1); i < len(handmade) {
+		return handmade[i]
+	}
+	return arena.Generate(prng.Int63(), code, teams, a.overworldBaseTiles)
 }
@@ -0,0 +1,44 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/baddy"
+	"github.com/griffithsh/squads/difficulty"
+	"github.com/griffithsh/squads/game/stats"
+	"github.com/griffithsh/squads/squad"
+)
+
+// expectedPower of a squad of level rolled from recipe, by the sum of the
+// attributes and health of the baddies it could contain, weighted by their
+// chance of joining.
+func expectedPower(a *Archive, model difficulty.Model, recipe squad.Recipe, level int) float64 {
+	result := 0.0
+	for _, candidate := range recipe {
+		char := baddy.Recipes[candidate.ID].Construct(nil)
+		equip := model.Scale(char, level)
+		attrs := stats.Derive(char, a.Profession(char.Profession), equip)
+		power := attrs.Strength + attrs.Agility + attrs.Intelligence + attrs.Vitality + attrs.MaxHealth
+		result += model.Chance(candidate, level) * float64(power)
+	}
+	return result
+}
+
+func TestPowerGrowsWithLevel(t *testing.T) {
+	a, err := NewArchive()
+	if err != nil {
+		t.Fatalf("NewArchive: %v", err)
+	}
+	model := a.Difficulty()
+
+	for id, recipe := range squad.Recipes {
+		previous := expectedPower(a, model, recipe, 1)
+		for level := 2; level <= 12; level++ {
+			power := expectedPower(a, model, recipe, level)
+			if power <= previous {
+				t.Errorf("squad %v: want power to grow from level %d to %d, got %.2f then %.2f", id, level-1, level, previous, power)
+			}
+			previous = power
+		}
+	}
+}
@@ -9,6 +9,7 @@ func (a *Archive) Profession(profession string) *game.ProfessionDetails {
 		return &game.ProfessionDetails{
 			ActionPoints: 40,
 			Preparation:  400,
+			Footprint:    game.MediumFootprint,
 		}
 	case "Skeleton":
 		return &game.ProfessionDetails{
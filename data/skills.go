@@ -16,9 +16,14 @@ import (
 
 type targetingJSON struct {
 	Selectable struct {
-		Type     targeting.SelectableType
-		MinRange int
-		MaxRange int
+		Type      targeting.SelectableType
+		MinRange  int
+		MaxRange  int
+		Direction geom.RelativeDirection
+		ArcRadius int
+		ArcBegin  int
+		ArcLength int
+		Filters   []targeting.TargetFilter
 	}
 	Brush struct {
 		Type            targeting.BrushType
@@ -26,7 +31,12 @@ type targetingJSON struct {
 		MaxRange        int
 		LinearExtent    int
 		LinearDirection geom.RelativeDirection
+		Direction       geom.RelativeDirection
+		ArcRadius       int
+		ArcBegin        int
+		ArcLength       int
 	}
+	Trajectory targeting.Trajectory
 }
 
 type skillEffect struct {
@@ -54,11 +64,16 @@ type skillDescription struct {
 
 	Costs map[string]int
 
+	Cooldown skill.Cooldown
+	Charges  int
+
 	// AttackChanceToHitModifier multiplies the base chance to hit of the skill.
 	// A value of zero does not modify the chance to hit. A value of 0.1
 	// improves the chance to hit by 10%. A value of -0.5 halves the chance to
 	// hit.
 	AttackChanceToHitModifier float64
+
+	Reaction skill.Reaction
 }
 
 // convert to a skill.Description
@@ -75,9 +90,14 @@ func (sd *skillDescription) convert() (skill.Description, error) {
 
 	targetingRule := targeting.Rule{
 		Selectable: targeting.Selectable{
-			Type:     sd.Targeting.Selectable.Type,
-			MinRange: sd.Targeting.Selectable.MinRange,
-			MaxRange: sd.Targeting.Selectable.MaxRange,
+			Type:      sd.Targeting.Selectable.Type,
+			MinRange:  sd.Targeting.Selectable.MinRange,
+			MaxRange:  sd.Targeting.Selectable.MaxRange,
+			Direction: sd.Targeting.Selectable.Direction,
+			ArcRadius: sd.Targeting.Selectable.ArcRadius,
+			ArcBegin:  sd.Targeting.Selectable.ArcBegin,
+			ArcLength: sd.Targeting.Selectable.ArcLength,
+			Filters:   sd.Targeting.Selectable.Filters,
 		},
 		Brush: targeting.Brush{
 			Type:            sd.Targeting.Brush.Type,
@@ -85,7 +105,12 @@ func (sd *skillDescription) convert() (skill.Description, error) {
 			MaxRange:        sd.Targeting.Brush.MaxRange,
 			LinearExtent:    sd.Targeting.Brush.LinearExtent,
 			LinearDirection: sd.Targeting.Brush.LinearDirection,
+			Direction:       sd.Targeting.Brush.Direction,
+			ArcRadius:       sd.Targeting.Brush.ArcRadius,
+			ArcBegin:        sd.Targeting.Brush.ArcBegin,
+			ArcLength:       sd.Targeting.Brush.ArcLength,
 		},
+		Trajectory: sd.Targeting.Trajectory,
 	}
 
 	effects := []skill.Effect{}
@@ -122,7 +147,10 @@ func (sd *skillDescription) convert() (skill.Description, error) {
 		Targeting:                 targetingRule,
 		Effects:                   effects,
 		Costs:                     costs,
+		Cooldown:                  sd.Cooldown,
+		Charges:                   sd.Charges,
 		AttackChanceToHitModifier: sd.AttackChanceToHitModifier,
+		Reaction:                  sd.Reaction,
 	}, nil
 }
 
@@ -192,8 +220,10 @@ func parseSkills(r io.Reader) ([]skill.Description, error) {
 	dec := json.NewDecoder(r)
 
 	result := []skill.Description{}
-	var s skillDescription
 	for {
+		// Each skill is decoded afresh, so that it inherits nothing from the
+		// skill before it.
+		var s skillDescription
 		err := dec.Decode(&s)
 		if err == io.EOF {
 			break
@@ -308,6 +338,48 @@ var internalSkills = []skill.Description{
 			},
 		},
 	},
+	{
+		ID:          "opportunity-attack",
+		Name:        "Opportunist",
+		Explanation: "Attack an adjacent tile, or an enemy that leaves it",
+		Tags:        []skill.Classification{skill.Attack},
+		Reaction:    skill.OpportunityReaction,
+		Icon: *game.Sprite{
+			Texture: "combat/hud.png",
+			X:       160,
+			Y:       0,
+			W:       24,
+			H:       24,
+		}.AsAnimation(),
+		Targeting: targeting.Rule{
+			Selectable: targeting.Selectable{
+				Type:     targeting.SelectWithin,
+				MinRange: 1,
+				MaxRange: 1,
+			},
+			Brush: targeting.Brush{
+				Type: targeting.SingleHex,
+			},
+		},
+		Costs: map[skill.CostType]int{
+			skill.CostsActionPoints: 20,
+		},
+		Effects: []skill.Effect{
+			{
+				When: skill.NewTiming(time.Millisecond * 500),
+				What: []interface{}{skill.DamageEffect{
+					Min: []skill.Operation{
+						{Operator: skill.AddOp, Variable: "$DMG-MIN"},
+					},
+					Max: []skill.Operation{
+						{Operator: skill.AddOp, Variable: "$DMG-MAX"},
+					},
+					Classification: skill.Attack,
+				},
+				},
+			},
+		},
+	},
 	{
 		ID:          "debug-lightning",
 		Name:        "Mage Lightning",
@@ -327,6 +399,7 @@ var internalSkills = []skill.Description{
 			Brush: targeting.Brush{
 				Type: targeting.SingleHex,
 			},
+			Trajectory: targeting.Arcing,
 		},
 		Costs: map[skill.CostType]int{
 			skill.CostsActionPoints: 45,
@@ -369,6 +442,7 @@ var internalSkills = []skill.Description{
 			Brush: targeting.Brush{
 				Type: targeting.SingleHex,
 			},
+			Trajectory: targeting.Arcing,
 		},
 		Costs: map[skill.CostType]int{
 			skill.CostsActionPoints: 45,
@@ -400,11 +474,13 @@ var internalSkills = []skill.Description{
 		}.AsAnimation(),
 		Targeting: targeting.Rule{
 			Selectable: targeting.Selectable{
-				Type: targeting.SelectAnywhere,
+				Type:    targeting.SelectAnywhere,
+				Filters: []targeting.TargetFilter{targeting.TargetKnockedDown},
 			},
 			Brush: targeting.Brush{
 				Type: targeting.SingleHex,
 			},
+			Trajectory: targeting.Arcing,
 		},
 		Costs: map[skill.CostType]int{
 			skill.CostsActionPoints: 65,
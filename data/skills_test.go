@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+
+	"github.com/griffithsh/squads/targeting"
 )
 
 func TestParseSkills(t *testing.T) {
@@ -26,16 +28,27 @@ func TestParseSkills(t *testing.T) {
         "selectable": {
             "type": "SelectWithin",
             "minRange": 1,
-            "maxRange": 1
+            "maxRange": 1,
+            "filters": ["TargetEnemy", "TargetKnockedDown"]
         },
         "brush": {
-            "type": "SingleHex"
-        }
+            "type": "Arc",
+            "direction": "Forward",
+            "arcRadius": 1,
+            "arcBegin": -1,
+            "arcLength": 3
+        },
+        "trajectory": "Direct"
     },
     "costs": {
         "CostsActionPoints": 20
     },
+    "cooldown": {
+        "turns": 2
+    },
+    "charges": 3,
     "attackChanceToHitModifier": -0.1,
+    "reaction": "CounterReaction",
     "effects": [
         {
             "when": 100,
@@ -81,8 +94,37 @@ func TestParseSkills(t *testing.T) {
 
 	encoded := strings.TrimSpace(b.String())
 
-	want := `{"ID":"basic-slash","Name":"Slash","Explanation":"Slash the target","Tags":["Attack"],"Icon":{"Frames":[{"texture":"any-file-name.png","x":0,"y":0,"w":24,"h":24,"offsetX":0,"offsetY":0}],"Timings":[5000000000],"Pointer":0,"EndBehavior":0},"Targeting":{"Selectable":{"Type":"SelectWithin","MinRange":1,"MaxRange":1},"Brush":{"Type":"SingleHex","MinRange":0,"MaxRange":0,"LinearExtent":0,"LinearDirection":"Forward"}},"Effects":[{"When":{},"What":[{"Min":[{"Operator":"AddOp","Variable":"$DMG-MIN"}],"Max":[{"Operator":"MultOp","Variable":"$DMG-MAX"}],"Classification":"Attack","DamageType":"FireDamage"}]}],"Costs":{"0":20},"AttackChanceToHitModifier":-0.1}`
+	want := `{"ID":"basic-slash","Name":"Slash","Explanation":"Slash the target","Tags":["Attack"],"Icon":{"Frames":[{"texture":"any-file-name.png","x":0,"y":0,"w":24,"h":24,"offsetX":0,"offsetY":0}],"Timings":[5000000000],"Pointer":0,"EndBehavior":0},"Targeting":{"Selectable":{"Type":"SelectWithin","MinRange":1,"MaxRange":1,"Direction":"Forward","ArcRadius":0,"ArcBegin":0,"ArcLength":0,"Filters":["TargetEnemy","TargetKnockedDown"]},"Brush":{"Type":"Arc","MinRange":0,"MaxRange":0,"LinearExtent":0,"LinearDirection":"Forward","Direction":"Forward","ArcRadius":1,"ArcBegin":-1,"ArcLength":3},"Trajectory":"Direct"},"Effects":[{"When":{},"What":[{"Min":[{"Operator":"AddOp","Variable":"$DMG-MIN"}],"Max":[{"Operator":"MultOp","Variable":"$DMG-MAX"}],"Classification":"Attack","DamageType":"FireDamage"}]}],"Costs":{"0":20},"Cooldown":{"Turns":2,"Preparation":0},"Charges":3,"AttackChanceToHitModifier":-0.1,"Reaction":"CounterReaction"}`
 	if encoded != want {
 		t.Errorf("want:\n\t%s\ngot:\n\t%s", want, encoded)
 	}
 }
+
+func TestParseSkillsTrajectory(t *testing.T) {
+	r := strings.NewReader(`{
+    "id": "lob",
+    "targeting": {
+        "selectable": {"type": "SelectWithin", "minRange": 1, "maxRange": 4},
+        "trajectory": "Arcing"
+    }
+}
+{
+    "id": "shoot",
+    "targeting": {
+        "selectable": {"type": "SelectWithin", "minRange": 1, "maxRange": 4}
+    }
+}`)
+	got, err := parseSkills(r)
+	if err != nil {
+		t.Fatalf("parseSkills: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("want 2 skills, got %d skills", len(got))
+	}
+	if got[0].Targeting.Trajectory != targeting.Arcing {
+		t.Errorf("want %s Arcing, got %v", got[0].ID, got[0].Targeting.Trajectory)
+	}
+	if got[1].Targeting.Trajectory != targeting.Direct {
+		t.Errorf("want %s to need line of sight by default, got %v", got[1].ID, got[1].Targeting.Trajectory)
+	}
+}
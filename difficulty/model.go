@@ -0,0 +1,97 @@
+// Package difficulty scales the opponents of an overworld by the level of the
+// path that the player picked.
+package difficulty
+
+import (
+	"math/rand"
+
+	"github.com/griffithsh/squads/baddy"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/item"
+	"github.com/griffithsh/squads/squad"
+)
+
+// Tier is how opponents of at least MinLevel are scaled.
+type Tier struct {
+	MinLevel int
+
+	// StatsPerLevel is the Strength, Agility, Intelligence and Vitality that
+	// baddies gain with each level.
+	StatsPerLevel float64
+
+	// HealthPerLevel is the BaseHealth that baddies gain with each level.
+	HealthPerLevel int
+
+	// Reinforcement is added to the Chance of every Candidate of a squad
+	// Recipe that is not certain to join the squad.
+	Reinforcement float64
+
+	// Equipment is equipped by every baddy.
+	Equipment []*item.Instance
+}
+
+// Model describes how opponents grow more difficult with level.
+type Model struct {
+	Tiers []Tier
+}
+
+// tier finds the highest Tier that opponents of level qualify for.
+func (m Model) tier(level int) *Tier {
+	var result *Tier
+	for i, tier := range m.Tiers {
+		if tier.MinLevel > level {
+			continue
+		}
+		if result == nil || tier.MinLevel > result.MinLevel {
+			result = &m.Tiers[i]
+		}
+	}
+	return result
+}
+
+// Chance of a Candidate joining a squad of level.
+func (m Model) Chance(candidate squad.Candidate, level int) float64 {
+	tier := m.tier(level)
+	if tier == nil || candidate.Chance >= 1 {
+		return candidate.Chance
+	}
+	if chance := candidate.Chance + tier.Reinforcement; chance < 1 {
+		return chance
+	}
+	return 1
+}
+
+// Squad rolls the members of a squad of level from a Recipe.
+func (m Model) Squad(rng *rand.Rand, recipe squad.Recipe, level int) []baddy.RecipeID {
+	result := make([]baddy.RecipeID, 0, len(recipe))
+	for _, candidate := range recipe {
+		if rng.Float64() < m.Chance(candidate, level) {
+			result = append(result, candidate.ID)
+		}
+	}
+	return result
+}
+
+// Scale a baddy to level, and return the Equipment it should carry, which is
+// nil when it carries nothing.
+func (m Model) Scale(char *game.Character, level int) *item.Equipment {
+	char.Level = level
+	tier := m.tier(level)
+	if tier == nil {
+		return nil
+	}
+	char.StrengthPerLevel += tier.StatsPerLevel
+	char.AgilityPerLevel += tier.StatsPerLevel
+	char.IntelligencePerLevel += tier.StatsPerLevel
+	char.VitalityPerLevel += tier.StatsPerLevel
+	char.BaseHealth += tier.HealthPerLevel * level
+
+	if len(tier.Equipment) == 0 {
+		return nil
+	}
+	equip := &item.Equipment{}
+	for _, it := range tier.Equipment {
+		equip.Equip(it.Clone())
+	}
+	return equip
+}
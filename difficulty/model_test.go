@@ -0,0 +1,58 @@
+package difficulty
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/item"
+	"github.com/griffithsh/squads/squad"
+)
+
+func TestScale(t *testing.T) {
+	model := Model{Tiers: []Tier{
+		{MinLevel: 5, StatsPerLevel: 2, HealthPerLevel: 10, Reinforcement: 0.75, Equipment: []*item.Instance{
+			{Class: item.RingClass, Modifiers: map[item.Modifier]float64{item.VitalityModifier: 1}},
+			{Class: item.RingClass, Modifiers: map[item.Modifier]float64{item.VitalityModifier: 2}},
+		}},
+		{MinLevel: 1, StatsPerLevel: 1, Reinforcement: 0.25},
+	}}
+
+	char := game.Character{}
+	if equip := model.Scale(&char, 0); equip != nil || char.Level != 0 || char.StrengthPerLevel != 0 {
+		t.Errorf("want nothing scaled below the lowest tier, got %+v", char)
+	}
+
+	char = game.Character{BaseHealth: 5}
+	if equip := model.Scale(&char, 3); equip != nil || char.Level != 3 || char.VitalityPerLevel != 1 || char.BaseHealth != 5 {
+		t.Errorf("unexpected low tier baddy %+v", char)
+	}
+
+	char = game.Character{}
+	equip := model.Scale(&char, 6)
+	if char.AgilityPerLevel != 2 || char.BaseHealth != 60 {
+		t.Errorf("unexpected high tier baddy %+v", char)
+	}
+	if equip == nil || equip.Ring1 == nil || equip.Ring2 == nil {
+		t.Fatalf("want both rings equipped, got %+v", equip)
+	}
+	equip.Ring1.Modifiers[item.VitalityModifier] = 99
+	if model.Tiers[0].Equipment[0].Modifiers[item.VitalityModifier] != 1 {
+		t.Errorf("want equipment independent of the model")
+	}
+
+	certain, optional := squad.Candidate{Chance: 1}, squad.Candidate{Chance: 0.5}
+	if got := model.Chance(certain, 9); got != 1 {
+		t.Errorf("want certain candidates unchanged, got %v", got)
+	}
+	if got := model.Chance(optional, 1); got != 0.75 {
+		t.Errorf("want 0.75, got %v", got)
+	}
+	if got := model.Chance(optional, 5); got != 1 {
+		t.Errorf("want reinforcement capped at certainty, got %v", got)
+	}
+	recipe := squad.Recipe{certain, optional, optional}
+	if got := model.Squad(rand.New(rand.NewSource(1)), recipe, 5); len(got) != 3 {
+		t.Errorf("want a full squad, got %v", got)
+	}
+}
@@ -38,6 +38,10 @@ type Character struct {
 	Profession string
 	Sex        CharacterSex
 
+	// Baddy names the baddy recipe that a computer-controlled Character was
+	// constructed from, and determines the loot it drops when defeated.
+	Baddy string
+
 	// InherantPreparation is the preparation value that this Character has as a
 	// base, before values from their profession and equipped weapon are
 	// applied.
@@ -50,7 +54,10 @@ type Character struct {
 	CurrentHealth int
 	BaseHealth    int
 
-	Level                int
+	Level int
+	// Experience accumulated towards the next Level.
+	Experience int
+
 	StrengthPerLevel     float64
 	AgilityPerLevel      float64
 	IntelligencePerLevel float64
@@ -60,6 +67,10 @@ type Character struct {
 
 	// Masteries indexed by the enum value.
 	Masteries map[Mastery]int
+
+	// MasteryProgress counts the uses of skills that train each Mastery, that
+	// have not yet advanced the Mastery.
+	MasteryProgress map[Mastery]int
 }
 
 // Type of this Component.
@@ -0,0 +1,89 @@
+package combat
+
+import (
+	"github.com/griffithsh/squads/approach"
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+)
+
+//go:generate stringer -type=Approach
+
+// Approach classifies the direction that an attack comes from, relative to
+// the direction that its target is facing.
+type Approach int
+
+const (
+	// FrontApproach is an attack from directly in front of the target.
+	FrontApproach Approach = iota
+	// SideApproach is an attack from forward-left or forward-right of the
+	// target.
+	SideApproach
+	// RearApproach is an attack from behind the target, including from
+	// back-left and back-right.
+	RearApproach
+)
+
+// approachLabels are how each Approach is presented to the player.
+var approachLabels = map[Approach]string{
+	FrontApproach: "FRONT",
+	SideApproach:  "SIDE",
+	RearApproach:  "REAR",
+}
+
+// approachOf classifies an attack from origin on a target at target that is
+// facing in the direction facing.
+func approachOf(origin, target geom.Key, facing geom.DirectionType) Approach {
+	if origin == target {
+		return FrontApproach
+	}
+	switch geom.Relativize(facing, geom.FindDirection(target, origin)) {
+	case geom.Forward:
+		return FrontApproach
+	case geom.ForwardLeft, geom.ForwardRight:
+		return SideApproach
+	default:
+		return RearApproach
+	}
+}
+
+// noApproachModifier does not modify an attack.
+var noApproachModifier = approach.Modifier{Damage: 1}
+
+// attackApproach works out the Approach of an attack by user on target, and
+// how the user's weapon modifies attacks from that Approach according to
+// table.
+func attackApproach(mgr *ecs.World, table approach.Table, user, target ecs.Entity) (Approach, approach.Modifier) {
+	userObstacle, ok := mgr.Component(user, "Obstacle").(*game.Obstacle)
+	if !ok {
+		return FrontApproach, noApproachModifier
+	}
+	targetObstacle, ok := mgr.Component(target, "Obstacle").(*game.Obstacle)
+	if !ok {
+		return FrontApproach, noApproachModifier
+	}
+	facer, ok := mgr.Component(target, "Facer").(*game.Facer)
+	if !ok {
+		return FrontApproach, noApproachModifier
+	}
+	participant, ok := mgr.Component(user, "Participant").(*Participant)
+	if !ok {
+		return FrontApproach, noApproachModifier
+	}
+
+	from := approachOf(
+		geom.Key{M: userObstacle.M, N: userObstacle.N},
+		geom.Key{M: targetObstacle.M, N: targetObstacle.N},
+		facer.Face,
+	)
+
+	modifiers := table.For(participant.EquippedWeaponClass)
+	switch from {
+	case SideApproach:
+		return from, modifiers.Side
+	case RearApproach:
+		return from, modifiers.Rear
+	default:
+		return from, noApproachModifier
+	}
+}
@@ -0,0 +1,74 @@
+package combat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/griffithsh/squads/data"
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/item"
+	"github.com/griffithsh/squads/geom"
+)
+
+func TestApproachOf(t *testing.T) {
+	target := geom.Key{M: 4, N: 4}
+	for _, tc := range []struct {
+		facing geom.DirectionType
+		from   geom.DirectionType
+		want   Approach
+	}{
+		{geom.S, geom.S, FrontApproach},
+		{geom.S, geom.SW, SideApproach},
+		{geom.S, geom.SE, SideApproach},
+		{geom.S, geom.NW, RearApproach},
+		{geom.S, geom.NE, RearApproach},
+		{geom.S, geom.N, RearApproach},
+		{geom.NE, geom.NE, FrontApproach},
+		{geom.NE, geom.N, SideApproach},
+		{geom.NE, geom.SW, RearApproach},
+	} {
+		t.Run(fmt.Sprintf("%v-from-%v", tc.facing, tc.from), func(t *testing.T) {
+			origin := target.ToDirection(tc.from)
+			if got := approachOf(origin, target, tc.facing); got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	// Ranged attacks from further away are rounded to the nearest direction.
+	if got := approachOf(geom.Key{M: 4, N: 0}, target, geom.S); got != RearApproach {
+		t.Errorf("want ranged attack from the north to be RearApproach, got %v", got)
+	}
+}
+
+func TestAttackApproach(t *testing.T) {
+	archive, err := data.NewArchive()
+	if err != nil {
+		t.Fatalf("NewArchive: %v", err)
+	}
+	table := archive.ApproachModifiers()
+
+	mgr := ecs.NewWorld()
+	target := mgr.NewEntity()
+	mgr.AddComponent(target, &game.Obstacle{M: 4, N: 4})
+	mgr.AddComponent(target, &game.Facer{Face: geom.S})
+	attack := func(class item.Class, from geom.DirectionType) (Approach, float64) {
+		user := mgr.NewEntity()
+		origin := geom.Key{M: 4, N: 4}.ToDirection(from)
+		mgr.AddComponent(user, &game.Obstacle{M: origin.M, N: origin.N})
+		mgr.AddComponent(user, &Participant{EquippedWeaponClass: class})
+		approach, modifier := attackApproach(mgr, table, user, target)
+		return approach, modifier.Damage
+	}
+
+	if approach, damage := attack(item.DaggerClass, geom.S); approach != FrontApproach || damage != 1 {
+		t.Errorf("want attacks from the front unmodified, got %v x%v", approach, damage)
+	}
+	if approach, damage := attack(item.DaggerClass, geom.N); approach != RearApproach || damage != 2 {
+		t.Errorf("want daggers to double damage from the rear, got %v x%v", approach, damage)
+	}
+	if approach, damage := attack(item.UnarmedClass, geom.SW); approach != SideApproach || damage != table.Default.Side.Damage || damage <= 1 {
+		t.Errorf("want unconfigured classes to use the default, got %v x%v", approach, damage)
+	}
+}
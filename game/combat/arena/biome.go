@@ -0,0 +1,130 @@
+package arena
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/overworld/hbg"
+	"github.com/griffithsh/squads/game/overworld/procedural"
+)
+
+// define the z-ordering render layers of visuals, which match the terrain and
+// participant layers of combat, so that features are sorted with participants.
+const (
+	groundLayer  = 10
+	featureLayer = 100
+)
+
+// tile is the overworld terrain whose base tile textures the ground of a hex.
+type tile procedural.Code
+
+const (
+	grassTile tile = "GRASS"
+	sandTile  tile = "SAND"
+	waterTile tile = "WATER"
+
+	// mudTile is the bare ground of sand, which stands out from grass.
+	mudTile = sandTile
+)
+
+// The dimensions of overworld base tiles, whose middles are cut out to fit
+// combat hexes.
+const (
+	baseTileW = 128
+	baseTileH = 64
+)
+
+// visual of the tile, from a variation of its overworld base tile in tiles.
+func (t tile) visual(tiles map[procedural.Code]hbg.BaseTile, prng *rand.Rand) game.CombatMapRecipeVisual {
+	bt, ok := tiles[procedural.Code(t)]
+	if !ok {
+		panic(fmt.Sprintf("no overworld base tile for %q", t))
+	}
+	option := bt.Variations.Roll(prng.Intn)
+	if option == nil {
+		panic(fmt.Sprintf("no variations of overworld base tile %q", t))
+	}
+	result := game.CombatMapRecipeVisual{Layer: groundLayer}
+	for _, frame := range option.Frames {
+		result.Frames = append(result.Frames, game.CombatMapRecipeHexFrame{
+			Texture:  bt.Texture,
+			X:        frame.Left + (baseTileW-72)/2,
+			Y:        frame.Top + option.ExtraHeight + (baseTileH-40)/2,
+			W:        72,
+			H:        40,
+			Duration: frame.Duration,
+		})
+	}
+	return result
+}
+
+// feature is a small sprite from embark/village-tiles.png that stands in a hex.
+type feature struct {
+	X int
+}
+
+func (f feature) visual() game.CombatMapRecipeVisual {
+	return game.CombatMapRecipeVisual{
+		Frames: []game.CombatMapRecipeHexFrame{{
+			Texture: "embark/village-tiles.png",
+			X:       f.X,
+			Y:       0,
+			W:       20,
+			H:       24,
+		}},
+		// Stand the feature in the middle of the hex.
+		XOffset: (72 - 20) / 2,
+		Layer:   featureLayer,
+	}
+}
+
+var (
+	trees  = []feature{{X: 0}, {X: 220}}
+	bushes = []feature{{X: 20}, {X: 40}}
+	tufts  = []feature{{X: 140}, {X: 160}, {X: 180}}
+)
+
+// biome describes how to fill a combat map for a kind of overworld terrain.
+// Chances are out of 100 for every hex that is not part of a start zone.
+type biome struct {
+	ground tile
+
+	// shore surrounds the map with water when it is true.
+	shore bool
+
+	trees, bushes, tufts, mud, pools int
+}
+
+var biomes = map[procedural.Code]biome{
+	"GRASS": {
+		ground: grassTile,
+		trees:  10,
+		bushes: 8,
+		tufts:  20,
+		mud:    5,
+	},
+	"SAND": {
+		ground: sandTile,
+		trees:  2,
+		bushes: 4,
+		tufts:  6,
+		pools:  4,
+	},
+	"WATER": {
+		ground: sandTile,
+		shore:  true,
+		bushes: 3,
+		tufts:  4,
+		pools:  2,
+	},
+}
+
+// biomeFor returns the biome for an overworld terrain Code, falling back to
+// grassland for Codes without one.
+func biomeFor(code procedural.Code) biome {
+	if b, ok := biomes[code]; ok {
+		return b
+	}
+	return biomes["GRASS"]
+}
@@ -0,0 +1,195 @@
+// Package arena procedurally generates combat maps.
+package arena
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/overworld/hbg"
+	"github.com/griffithsh/squads/game/overworld/procedural"
+	"github.com/griffithsh/squads/geom"
+)
+
+// The dimensions of generated combat maps, in hexes.
+const (
+	width  = 14
+	height = 10
+)
+
+// startRadius is how many hexes around a start are kept clear so that a team
+// has room to take its place.
+const startRadius = 2
+
+// Generate a combat map to fight on the overworld terrain code, for the given
+// number of teams. The ground is painted with the overworld base tiles. The
+// same seed always produces the same map.
+func Generate(seed int64, code procedural.Code, teams int, tiles map[procedural.Code]hbg.BaseTile) *game.CombatMapRecipe {
+	prng := rand.New(rand.NewSource(seed))
+	b := biomeFor(code)
+	paint := func(t tile) game.CombatMapRecipeVisual {
+		return t.visual(tiles, prng)
+	}
+
+	starts := startsFor(prng, teams)
+	zone := map[geom.Key]bool{}
+	for _, s := range starts {
+		for _, c := range geom.Spiral(s.Cube(), startRadius) {
+			zone[c.Key()] = true
+		}
+	}
+
+	// keys preserves the order that hexes were added in, so that the same seed
+	// always produces the same recipe.
+	keys := []geom.Key{}
+	hexes := map[geom.Key]*game.CombatMapRecipeHex{}
+	for m := 0; m < width; m++ {
+		for n := 0; n < height; n++ {
+			k := geom.Key{M: m, N: n}
+			border := m == 0 || n == 0 || m == width-1 || n == height-1
+			// Rough up the edges.
+			if border && !b.shore && !zone[k] && prng.Intn(100) < 40 {
+				continue
+			}
+			keys = append(keys, k)
+			hexes[k] = &game.CombatMapRecipeHex{Position: k}
+		}
+	}
+
+	for _, k := range keys {
+		h := hexes[k]
+		h.Visuals = append(h.Visuals, paint(b.ground))
+		if b.shore {
+			switch edgeDistance(k) {
+			case 0:
+				// Participants can wade out of the combat through the deep
+				// water.
+				h.Obstacle = game.DeepWaterObstacle
+				h.Escape = true
+				h.Visuals = []game.CombatMapRecipeVisual{paint(waterTile)}
+				continue
+			case 1:
+				h.Terrain = game.ShallowWaterTerrain
+				h.Visuals = []game.CombatMapRecipeVisual{paint(waterTile)}
+				continue
+			}
+		}
+		if zone[k] {
+			continue
+		}
+
+		roll := prng.Intn(100)
+		switch {
+		case roll < b.trees:
+			h.Obstacle = game.TreeObstacle
+			h.Terrain = game.ForestTerrain
+			h.Visuals = append(h.Visuals, trees[prng.Intn(len(trees))].visual())
+		case roll < b.trees+b.bushes:
+			h.Terrain = game.ForestTerrain
+			h.Cover = 0.25
+			h.Visuals = append(h.Visuals, bushes[prng.Intn(len(bushes))].visual())
+		case roll < b.trees+b.bushes+b.mud:
+			h.Obstacle = game.MudObstacle
+			h.Terrain = game.MudTerrain
+			h.Visuals = []game.CombatMapRecipeVisual{paint(mudTile)}
+		case roll < b.trees+b.bushes+b.mud+b.pools:
+			h.Obstacle = game.DeepWaterObstacle
+			h.Visuals = []game.CombatMapRecipeVisual{paint(waterTile)}
+		case roll < b.trees+b.bushes+b.mud+b.pools+b.tufts:
+			h.Visuals = append(h.Visuals, tufts[prng.Intn(len(tufts))].visual())
+		}
+	}
+
+	// Every start must be able to reach every other start.
+	for _, s := range starts[1:] {
+		if connected(hexes, starts[0], s) {
+			continue
+		}
+		for _, k := range geom.Line(starts[0], s) {
+			h, ok := hexes[k]
+			if !ok {
+				h = &game.CombatMapRecipeHex{Position: k}
+				keys = append(keys, k)
+				hexes[k] = h
+			}
+			if passable(h) {
+				continue
+			}
+			h.Obstacle = game.NonObstacle
+			h.Terrain = game.OpenTerrain
+			h.Cover = 0
+			h.Visuals = []game.CombatMapRecipeVisual{paint(b.ground)}
+		}
+	}
+
+	result := game.CombatMapRecipe{
+		Starts: starts,
+		TileW:  72,
+		TileH:  40,
+	}
+	for _, k := range keys {
+		result.Hexes = append(result.Hexes, *hexes[k])
+	}
+	return &result
+}
+
+// startsFor spreads the starts of teams evenly around the middle of the map.
+func startsFor(prng *rand.Rand, teams int) []geom.Key {
+	if teams < 2 {
+		teams = 2
+	}
+	// Inset the starts far enough that their whole zone is on the map.
+	rx := float64(width/2 - startRadius - 1)
+	ry := float64(height/2 - startRadius - 1)
+	cx, cy := float64(width-1)/2, float64(height-1)/2
+	turn := prng.Float64() * math.Pi / 6
+
+	result := make([]geom.Key, 0, teams)
+	for i := 0; i < teams; i++ {
+		angle := math.Pi + turn + 2*math.Pi*float64(i)/float64(teams)
+		result = append(result, geom.Key{
+			M: int(math.Round(cx + rx*math.Cos(angle))),
+			N: int(math.Round(cy + ry*math.Sin(angle))),
+		})
+	}
+	return result
+}
+
+// edgeDistance is how many hexes k is from the edge of the map.
+func edgeDistance(k geom.Key) int {
+	d := k.M
+	for _, e := range []int{k.N, width - 1 - k.M, height - 1 - k.N} {
+		if e < d {
+			d = e
+		}
+	}
+	return d
+}
+
+// passable determines whether a Character can walk through the hex.
+func passable(h *game.CombatMapRecipeHex) bool {
+	return h.Obstacle == game.NonObstacle || h.Obstacle == game.MudObstacle
+}
+
+// connected determines whether there is a passable path from a to b.
+func connected(hexes map[geom.Key]*game.CombatMapRecipeHex, a, b geom.Key) bool {
+	seen := map[geom.Key]bool{a: true}
+	queue := []geom.Key{a}
+	for len(queue) > 0 {
+		k := queue[0]
+		queue = queue[1:]
+		if k == b {
+			return true
+		}
+		for _, dir := range geom.DirectionTypeValues() {
+			next := k.ToDirection(dir)
+			h, ok := hexes[next]
+			if !ok || seen[next] || !passable(h) {
+				continue
+			}
+			seen[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package arena
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/griffithsh/squads/embedded"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/overworld/hbg"
+	"github.com/griffithsh/squads/game/overworld/procedural"
+	"github.com/griffithsh/squads/geom"
+)
+
+// baseTiles loads the overworld base tiles that paint the ground.
+func baseTiles(t *testing.T) map[procedural.Code]hbg.BaseTile {
+	t.Helper()
+	result := map[procedural.Code]hbg.BaseTile{}
+	for _, name := range []string{"grass", "sand", "water"} {
+		b, err := embedded.Get(fmt.Sprintf("overworld/tiles/%s.obt.json", name))
+		if err != nil {
+			t.Fatalf("get %s: %v", name, err)
+		}
+		var bt hbg.BaseTile
+		if err := json.Unmarshal(b, &bt); err != nil {
+			t.Fatalf("parse %s: %v", name, err)
+		}
+		result[bt.Code] = bt
+	}
+	return result
+}
+
+func TestGenerate(t *testing.T) {
+	tiles := baseTiles(t)
+	for _, code := range []procedural.Code{"GRASS", "SAND", "WATER", "UNKNOWN"} {
+		for _, teams := range []int{2, 3} {
+			for i := 0; i < 1000; i++ {
+				seed := int64(i) + 123456789
+				t.Run(fmt.Sprintf("%s-%d-seed=%v", code, teams, seed), func(t *testing.T) {
+					recipe := Generate(seed, code, teams, tiles)
+
+					if len(recipe.Starts) != teams {
+						t.Fatalf("want %d starts, got %d", teams, len(recipe.Starts))
+					}
+
+					hexes := map[geom.Key]*game.CombatMapRecipeHex{}
+					for i, h := range recipe.Hexes {
+						if _, ok := hexes[h.Position]; ok {
+							t.Fatalf("duplicate hex %v", h.Position)
+						}
+						hexes[h.Position] = &recipe.Hexes[i]
+						ground := h.Visuals[0].Frames[0]
+						if ground.Texture != tiles["GRASS"].Texture && ground.Texture != tiles["SAND"].Texture && ground.Texture != tiles["WATER"].Texture {
+							t.Fatalf("hex %v is not painted with terrain, got %q", h.Position, ground.Texture)
+						}
+					}
+					for _, s := range recipe.Starts {
+						h, ok := hexes[s]
+						if !ok {
+							t.Fatalf("missing start %v", s)
+						}
+						if !passable(h) {
+							t.Errorf("start %v is blocked by %v", s, h.Obstacle)
+						}
+						if !connected(hexes, recipe.Starts[0], s) {
+							t.Errorf("start %v cannot reach %v", s, recipe.Starts[0])
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestGenerateIsSeeded(t *testing.T) {
+	tiles := baseTiles(t)
+	a := Generate(42, "GRASS", 2, tiles)
+	b := Generate(42, "GRASS", 2, tiles)
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("the same seed generated different maps")
+	}
+	c := Generate(43, "GRASS", 2, tiles)
+	if reflect.DeepEqual(a, c) {
+		t.Errorf("different seeds generated the same map")
+	}
+}
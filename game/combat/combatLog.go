@@ -0,0 +1,253 @@
+package combat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+)
+
+// LogEntry is one line of a CombatLog.
+type LogEntry struct {
+	// Turn counts the turns that had begun when the entry was logged.
+	Turn   int
+	Event  event.Type
+	Actor  string `json:",omitempty"`
+	Target string `json:",omitempty"`
+	Amount int    `json:",omitempty"`
+	Text   string
+}
+
+// Tally is what one Participant did during a combat.
+type Tally struct {
+	DamageDealt int
+	DamageTaken int
+	Kills       int
+	SkillsUsed  int
+	Turns       int
+}
+
+// damageCause is what damaged a Participant: another Participant, a Hazard,
+// or, when neither is set, bleeding.
+type damageCause struct {
+	source ecs.Entity
+	hazard game.HazardType
+}
+
+// hazardNames describe each HazardType in the log.
+var hazardNames = map[game.HazardType]string{
+	game.FireHazard:   "fire",
+	game.PoisonHazard: "poison",
+	game.SpikesHazard: "spikes",
+}
+
+// CombatLog records what happens in a combat, so that it can be reviewed
+// during the combat and exported once it is over.
+type CombatLog struct {
+	mgr     *ecs.World
+	archive SkillArchive
+
+	entries []LogEntry
+	turn    int
+
+	// preparing is set while time is passing. Stats are modified every frame
+	// while preparing, and that is not worth recording.
+	preparing bool
+
+	// lastCause is what most recently damaged each Participant, so that it
+	// can be credited with knocking it down.
+	lastCause map[ecs.Entity]damageCause
+
+	tallies map[ecs.Entity]*Tally
+}
+
+// NewCombatLog constructs a CombatLog that records the events of every combat
+// published on bus.
+func NewCombatLog(mgr *ecs.World, bus *event.Bus, archive SkillArchive) *CombatLog {
+	cl := CombatLog{
+		mgr:       mgr,
+		archive:   archive,
+		lastCause: map[ecs.Entity]damageCause{},
+		tallies:   map[ecs.Entity]*Tally{},
+	}
+	bus.Subscribe(game.CombatBegan{}.Type(), cl.handleCombatBegan)
+	bus.Subscribe(StateTransition{}.Type(), cl.handleStateTransition)
+	bus.Subscribe(ParticipantTurnChanged{}.Type(), cl.handleParticipantTurnChanged)
+	bus.Subscribe(UsingSkill{}.Type(), cl.handleUsingSkill)
+	bus.Subscribe(DamageAccepted{}.Type(), cl.handleDamageAccepted)
+	bus.Subscribe(DamageFailed{}.Type(), cl.handleDamageFailed)
+	bus.Subscribe(InjuryApplied{}.Type(), cl.handleInjuryApplied)
+	bus.Subscribe(ParticipantDied{}.Type(), cl.handleParticipantDied)
+	bus.Subscribe(ParticipantRevived{}.Type(), cl.handleParticipantRevived)
+	bus.Subscribe(StatModified{}.Type(), cl.handleStatModified)
+
+	return &cl
+}
+
+// Entries of the log, from oldest to newest.
+func (cl *CombatLog) Entries() []LogEntry {
+	return cl.entries
+}
+
+// Tally of what the Participant e has done in the combat.
+func (cl *CombatLog) Tally(e ecs.Entity) Tally {
+	if tally, ok := cl.tallies[e]; ok {
+		return *tally
+	}
+	return Tally{}
+}
+
+func (cl *CombatLog) tally(e ecs.Entity) *Tally {
+	tally, ok := cl.tallies[e]
+	if !ok {
+		tally = &Tally{}
+		cl.tallies[e] = tally
+	}
+	return tally
+}
+
+// WriteText writes the log as a human-readable transcript.
+func (cl *CombatLog) WriteText(w io.Writer) error {
+	for _, entry := range cl.entries {
+		if _, err := fmt.Fprintf(w, "%4d  %s\n", entry.Turn, entry.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the log as a JSON array of LogEntries.
+func (cl *CombatLog) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(cl.entries)
+}
+
+func (cl *CombatLog) name(e ecs.Entity) string {
+	if participant, ok := cl.mgr.Component(e, "Participant").(*Participant); ok {
+		return participant.Name
+	}
+	return fmt.Sprintf("Entity(%d)", e)
+}
+
+func (cl *CombatLog) add(t event.Typer, actor, target ecs.Entity, amount int, text string) {
+	entry := LogEntry{
+		Turn:   cl.turn,
+		Event:  t.Type(),
+		Amount: amount,
+		Text:   text,
+	}
+	if actor != 0 {
+		entry.Actor = cl.name(actor)
+	}
+	if target != 0 {
+		entry.Target = cl.name(target)
+	}
+	cl.entries = append(cl.entries, entry)
+}
+
+func (cl *CombatLog) handleCombatBegan(event.Typer) {
+	cl.entries = nil
+	cl.turn = 0
+	cl.preparing = false
+	cl.lastCause = map[ecs.Entity]damageCause{}
+	cl.tallies = map[ecs.Entity]*Tally{}
+}
+
+func (cl *CombatLog) handleStateTransition(t event.Typer) {
+	ev := t.(*StateTransition)
+	cl.preparing = ev.New.Value() == PreparingState
+}
+
+func (cl *CombatLog) handleParticipantTurnChanged(t event.Typer) {
+	ev := t.(*ParticipantTurnChanged)
+	if ev.Entity == 0 {
+		// Nobody's turn, for instance after a successful escape.
+		return
+	}
+	cl.turn++
+	cl.tally(ev.Entity).Turns++
+	cl.add(ev, ev.Entity, 0, 0, fmt.Sprintf("%s's turn", cl.name(ev.Entity)))
+}
+
+func (cl *CombatLog) handleUsingSkill(t event.Typer) {
+	ev := t.(*UsingSkill)
+	name := string(ev.Skill)
+	if s := cl.archive.Skill(ev.Skill); s != nil && s.Name != "" {
+		name = s.Name
+	}
+	cl.tally(ev.User).SkillsUsed++
+	verb := "uses"
+	if ev.Reaction {
+		verb = "reacts with"
+	}
+	cl.add(ev, ev.User, 0, 0, fmt.Sprintf("%s %s %s", cl.name(ev.User), verb, name))
+}
+
+func (cl *CombatLog) handleDamageAccepted(t event.Typer) {
+	ev := t.(*DamageAccepted)
+	cl.lastCause[ev.Target] = damageCause{source: ev.Source, hazard: ev.Hazard}
+	cl.tally(ev.Target).DamageTaken += ev.Amount
+	switch {
+	case ev.Hazard != game.NoHazard:
+		cl.add(ev, 0, ev.Target, ev.Amount, fmt.Sprintf("%s takes %d %v from %s", cl.name(ev.Target), ev.Amount, ev.DamageType, hazardNames[ev.Hazard]))
+		return
+	case ev.Source == 0:
+		cl.add(ev, 0, ev.Target, ev.Amount, fmt.Sprintf("%s bleeds for %d", cl.name(ev.Target), ev.Amount))
+		return
+	}
+	cl.tally(ev.Source).DamageDealt += ev.Amount
+	text := fmt.Sprintf("%s hits %s for %d %v", cl.name(ev.Source), cl.name(ev.Target), ev.Amount, ev.DamageType)
+	if ev.Reduced > 0 {
+		text += fmt.Sprintf(" (%d reduced)", ev.Reduced)
+	}
+	cl.add(ev, ev.Source, ev.Target, ev.Amount, text)
+}
+
+func (cl *CombatLog) handleDamageFailed(t event.Typer) {
+	ev := t.(*DamageFailed)
+	cl.add(ev, 0, ev.Target, 0, fmt.Sprintf("%s: %s", cl.name(ev.Target), ev.Reason))
+}
+
+func (cl *CombatLog) handleInjuryApplied(t event.Typer) {
+	ev := t.(*InjuryApplied)
+	cl.add(ev, 0, ev.Target, ev.Value, fmt.Sprintf("%s suffers %v %d", cl.name(ev.Target), ev.InjuryType, ev.Value))
+}
+
+func (cl *CombatLog) handleParticipantDied(t event.Typer) {
+	ev := t.(*ParticipantDied)
+	cause, ok := cl.lastCause[ev.Entity]
+	switch {
+	case !ok:
+		cl.add(ev, 0, ev.Entity, 0, fmt.Sprintf("%s is knocked down", cl.name(ev.Entity)))
+	case cause.hazard != game.NoHazard:
+		cl.add(ev, 0, ev.Entity, 0, fmt.Sprintf("%s is knocked down by %s", cl.name(ev.Entity), hazardNames[cause.hazard]))
+	case cause.source == 0:
+		cl.add(ev, 0, ev.Entity, 0, fmt.Sprintf("%s bleeds out", cl.name(ev.Entity)))
+	default:
+		cl.tally(cause.source).Kills++
+		cl.add(ev, cause.source, ev.Entity, 0, fmt.Sprintf("%s knocks down %s", cl.name(cause.source), cl.name(ev.Entity)))
+	}
+}
+
+func (cl *CombatLog) handleParticipantRevived(t event.Typer) {
+	ev := t.(*ParticipantRevived)
+	delete(cl.lastCause, ev.Entity)
+	cl.add(ev, 0, ev.Entity, 0, fmt.Sprintf("%s is revived", cl.name(ev.Entity)))
+}
+
+var statAbbreviations = map[game.StatType]string{
+	game.HPStat:     "HP",
+	game.EnergyStat: "EN",
+	game.ActionStat: "AP",
+	game.PrepStat:   "PR",
+}
+
+func (cl *CombatLog) handleStatModified(t event.Typer) {
+	ev := t.(*StatModified)
+	if cl.preparing || ev.Amount == 0 {
+		return
+	}
+	cl.add(ev, ev.Entity, 0, ev.Amount, fmt.Sprintf("%s %+d %s", cl.name(ev.Entity), ev.Amount, statAbbreviations[ev.Stat]))
+}
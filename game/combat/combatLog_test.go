@@ -0,0 +1,125 @@
+package combat
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/skill"
+)
+
+func TestCombatLog(t *testing.T) {
+	mgr := ecs.NewWorld()
+	bus := &event.Bus{}
+	archive := testArchive{"slash": {ID: "slash", Name: "Slash"}}
+	log := NewCombatLog(mgr, bus, archive)
+
+	alice := mgr.NewEntity()
+	mgr.AddComponent(alice, &Participant{Name: "Alice"})
+	bob := mgr.NewEntity()
+	mgr.AddComponent(bob, &Participant{Name: "Bob"})
+
+	bus.Publish(&game.CombatBegan{})
+	bus.Publish(&StateTransition{New: PreparingState})
+	bus.Publish(&StatModified{Entity: alice, Stat: game.PrepStat, Amount: 30})
+	bus.Publish(&ParticipantTurnChanged{Entity: alice})
+	bus.Publish(&StateTransition{New: AwaitingInputState})
+	bus.Publish(&UsingSkill{User: alice, Skill: "slash"})
+	bus.Publish(&StatModified{Entity: alice, Stat: game.ActionStat, Amount: -20})
+	bus.Publish(&DamageFailed{Target: bob, Reason: "Dodged"})
+	bus.Publish(&DamageAccepted{Target: bob, Source: alice, Amount: 12, Reduced: 3})
+	bus.Publish(&InjuryApplied{Target: bob, InjuryType: skill.BleedingInjury, Value: 5})
+	bus.Publish(&DamageAccepted{Target: bob, Amount: 4})
+	bus.Publish(&ParticipantDied{Entity: bob})
+	bus.Publish(&ParticipantRevived{Entity: bob})
+
+	want := []string{
+		"Alice's turn",
+		"Alice uses Slash",
+		"Alice -20 AP",
+		"Bob: Dodged",
+		"Alice hits Bob for 12 PhysicalDamage (3 reduced)",
+		"Bob suffers BleedingInjury 5",
+		"Bob bleeds for 4",
+		"Bob bleeds out",
+		"Bob is revived",
+	}
+	entries := log.Entries()
+	if len(entries) != len(want) {
+		t.Fatalf("want %d entries, got %d: %+v", len(want), len(entries), entries)
+	}
+	for i, entry := range entries {
+		if entry.Text != want[i] {
+			t.Errorf("entry %d: want %q, got %q", i, want[i], entry.Text)
+		}
+		if entry.Turn != 1 {
+			t.Errorf("entry %d: want turn 1, got %d", i, entry.Turn)
+		}
+	}
+
+	var text bytes.Buffer
+	if err := log.WriteText(&text); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	if got := strings.Count(text.String(), "\n"); got != len(want) {
+		t.Errorf("want %d lines of text, got %d", len(want), got)
+	}
+
+	var buf bytes.Buffer
+	if err := log.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	decoded := []LogEntry{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("unmarshal transcript: %v", err)
+	}
+	if hit := decoded[4]; hit.Actor != "Alice" || hit.Target != "Bob" || hit.Amount != 12 || hit.Event != "combat.DamageAccepted" {
+		t.Errorf("unexpected hit %+v", hit)
+	}
+
+	bus.Publish(&game.CombatBegan{})
+	if len(log.Entries()) != 0 {
+		t.Errorf("want the log cleared when a new combat begins")
+	}
+}
+
+func TestCombatLogHazards(t *testing.T) {
+	mgr := ecs.NewWorld()
+	bus := &event.Bus{}
+	log := NewCombatLog(mgr, bus, testArchive{})
+
+	alice := mgr.NewEntity()
+	mgr.AddComponent(alice, &Participant{Name: "Alice"})
+	bob := mgr.NewEntity()
+	mgr.AddComponent(bob, &Participant{Name: "Bob"})
+
+	bus.Publish(&game.CombatBegan{})
+	bus.Publish(&DamageAccepted{Target: bob, Source: alice, Amount: 5, DamageType: game.PhysicalDamage})
+	bus.Publish(&DamageAccepted{Target: bob, Amount: 8, DamageType: game.FireDamage, Hazard: game.FireHazard})
+	bus.Publish(&ParticipantDied{Entity: bob})
+
+	want := []string{
+		"Alice hits Bob for 5 PhysicalDamage",
+		"Bob takes 8 FireDamage from fire",
+		"Bob is knocked down by fire",
+	}
+	entries := log.Entries()
+	if len(entries) != len(want) {
+		t.Fatalf("want %d entries, got %d: %+v", len(want), len(entries), entries)
+	}
+	for i, entry := range entries {
+		if entry.Text != want[i] {
+			t.Errorf("entry %d: want %q, got %q", i, want[i], entry.Text)
+		}
+	}
+	if kills := log.Tally(alice).Kills; kills != 0 {
+		t.Errorf("want no kill credited for a hazard, got %d", kills)
+	}
+	if taken := log.Tally(bob).DamageTaken; taken != 13 {
+		t.Errorf("want 13 damage taken, got %d", taken)
+	}
+}
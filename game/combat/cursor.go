@@ -2,6 +2,7 @@ package combat
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/griffithsh/squads/ecs"
@@ -17,6 +18,7 @@ var (
 	cursorsTag          = "CURSORS_TAG"
 	liveParticipantsTag = cursorsTag + ".LIVE_PARTICIPANTS"
 	pathNavigationTag   = cursorsTag + ".PATH_NAVIGATION"
+	pathCostTag         = cursorsTag + ".PATH_COST"
 
 	invalidatedCursorsTag = cursorsTag + ".INVALIDATED"
 )
@@ -37,6 +39,17 @@ type CursorManager struct {
 
 	targeting *targeting.Rule
 
+	// skill is being targeted while the combat is in SelectingTargetState.
+	skill *skill.Description
+
+	// targets are the hexes that skill can target. They are found once per
+	// selection of a skill rather than on every repaint.
+	targets []geom.Key
+
+	// deploying is where the local player may arrange their squad while the
+	// combat is in DeployingState.
+	deploying *deployingState
+
 	lastState State
 }
 
@@ -82,6 +95,10 @@ func (cm *CursorManager) handleDifferentHexSelected(ev event.Typer) {
 		s := cm.archive.Skill(ctx.Skill)
 		cm.selectedKey = value.K
 		cm.targeting = &s.Targeting
+		if cm.skill == nil || cm.skill.ID != s.ID {
+			cm.targets = nil
+		}
+		cm.skill = s
 
 		cm.showHighlightedHexes()
 
@@ -90,17 +107,26 @@ func (cm *CursorManager) handleDifferentHexSelected(ev event.Typer) {
 		s := cm.archive.Skill(ctx.Skill)
 		cm.selectedKey = value.K
 		cm.targeting = &s.Targeting
+		cm.skill = nil
+
+		cm.showHighlightedHexes()
+
+	case DeployingState:
+		cm.selectedKey = value.K
+		cm.deploying = value.Context.(*deployingState)
 
 		cm.showHighlightedHexes()
 
 	default:
 		cm.selectedKey = nil
 		cm.targeting = nil
+		cm.skill = nil
 		cm.hideHighlightedHexes()
 	}
 }
 
 func (cm *CursorManager) handleCombatStateTransition(ev event.Typer) {
+	cm.targets = nil
 	cm.hideHighlightedHexes()
 }
 
@@ -123,14 +149,16 @@ func (cm *CursorManager) Update(elapsed time.Duration) {
 	}
 }
 
-const maxLiveParticipants int = 25
+// maxLiveParticipantCursors is enough for every hex of several Participants,
+// because Participants with larger Footprints need a cursor for each hex.
+const maxLiveParticipantCursors int = 60
 
 func (cm *CursorManager) showLiveParticipants() {
 	for _, e := range cm.mgr.Tagged(liveParticipantsTag) {
 		cm.mgr.DestroyEntity(e)
 	}
 
-	for i := 0; i < maxLiveParticipants; i++ {
+	for i := 0; i < maxLiveParticipantCursors; i++ {
 		e := cm.mgr.NewEntity()
 		cm.mgr.Tag(e, "combat")
 
@@ -140,21 +168,28 @@ func (cm *CursorManager) showLiveParticipants() {
 }
 
 func (cm *CursorManager) repaintLiveParticipants() {
-	entities := cm.mgr.Get([]string{"Participant"})
-
-	// liveEntity determines if an there is an alive entity at index i of entities.
-	liveEntity := func(i int) bool {
-		if i < len(entities) {
-			participant := cm.mgr.Component(entities[i], "Participant").(*Participant)
-			if participant.Status == Alive {
-				return true
-			}
+	// leashes holds a cursor Leash for every hex of every alive Participant.
+	leashes := []game.Leash{}
+	for _, e := range cm.mgr.Get([]string{"Participant", "Obstacle", "Position"}) {
+		participant := cm.mgr.Component(e, "Participant").(*Participant)
+		if participant.Status != Alive {
+			continue
+		}
+		obstacle := cm.mgr.Component(e, "Obstacle").(*game.Obstacle)
+		cx, cy := footprintCenter(cm.field, obstacle.Footprint, geom.Key{M: obstacle.M, N: obstacle.N})
+		for _, k := range obstacle.Keys() {
+			x, y := cm.field.Ktow(k)
+			leashes = append(leashes, game.Leash{
+				Owner:       e,
+				LayerOffset: -5,
+				X:           x - cx,
+				Y:           y - cy,
+			})
 		}
-		return false
 	}
 
 	for i, slot := range cm.mgr.Tagged(liveParticipantsTag) {
-		if liveEntity(i) {
+		if i < len(leashes) {
 			spr := game.Sprite{
 				Texture: "combat/cursors.png",
 
@@ -162,10 +197,7 @@ func (cm *CursorManager) repaintLiveParticipants() {
 				W: hexagonTileWidth, H: hexagonHeight,
 			}
 			cm.mgr.AddComponent(slot, &spr)
-			cm.mgr.AddComponent(slot, &game.Leash{
-				Owner:       entities[i],
-				LayerOffset: -5,
-			})
+			cm.mgr.AddComponent(slot, &leashes[i])
 		} else {
 			// hide cursor
 			cm.mgr.RemoveComponent(slot, &game.Sprite{})
@@ -176,14 +208,14 @@ func (cm *CursorManager) repaintLiveParticipants() {
 	}
 }
 
-const maxPathNavigationCursors int = 100 // FIXME: could be dynamic based on number of hexes in field?
-
 func (cm *CursorManager) showHighlightedHexes() {
 	for _, e := range cm.mgr.Tagged(pathNavigationTag) {
 		cm.mgr.DestroyEntity(e)
 	}
 
-	for i := 0; i < maxPathNavigationCursors; i++ {
+	// Every hex of the field could be painted by the move-range preview, and
+	// then again by the path on top of it.
+	for i := 0; i < 2*len(cm.field.Hexes()); i++ {
 		e := cm.mgr.NewEntity()
 		cm.mgr.Tag(e, "combat")
 
@@ -196,32 +228,167 @@ func (cm *CursorManager) hideHighlightedHexes() {
 	for _, e := range cm.mgr.Tagged(pathNavigationTag) {
 		cm.mgr.DestroyEntity(e)
 	}
+	cm.hidePathCost()
 }
 
-func (cm *CursorManager) repaintHighlightedHexes() {
-	type cursorSprite struct {
-		// s is the sprite to use
-		s game.Sprite
-		// p is where to paint it
-		p game.Position
+type cursorSprite struct {
+	// s is the sprite to use
+	s game.Sprite
+	// p is where to paint it
+	p game.Position
+}
+
+// hexCursor creates a cursorSprite to paint the cell at col,row of the cursors
+// texture over the hex at k.
+func (cm *CursorManager) hexCursor(k geom.Key, col, row int, layer int) cursorSprite {
+	x, y := cm.field.Ktow(k)
+	return cursorSprite{
+		s: game.Sprite{
+			Texture: "combat/cursors.png",
+
+			X: hexagonTileWidth * col, Y: hexagonHeight * row,
+			W: hexagonTileWidth, H: hexagonHeight,
+		},
+		p: game.Position{
+			Center: game.Center{
+				X: x,
+				Y: y,
+			},
+			Layer: layer,
+		},
 	}
+}
+
+// moveRangePaints previews where the Participant whose turn it is can move.
+// Hexes it can reach this turn are tinted green, hexes it could reach by the
+// end of its next turn are tinted amber, and impassable hexes at the edge of
+// this turn's range are hatched.
+func (cm *CursorManager) moveRangePaints() (*graph.Reachable, []cursorSprite) {
+	participant := cm.mgr.Component(cm.turnToken, "Participant").(*Participant)
+	ap, maxAP := float64(participant.ActionPoints.Cur), float64(participant.ActionPoints.Max)
+
+	// Use the same range as the IntentSystem, so that the preview agrees with
+	// the actual movement.
+	reachable := MoveRange(cm.field, cm.mgr, cm.turnToken)
 
 	paints := []cursorSprite{}
+	cost := CostsFuncFactory(cm.field, cm.mgr, cm.turnToken)
+	edges := EdgeFuncFactory(cm.field)
+	blocked := map[geom.Key]struct{}{}
+
+	for _, h := range cm.field.Hexes() {
+		k := h.Key()
+		c, ok := reachable.Costs[k]
+		if !ok || k == reachable.Start {
+			continue
+		}
+		switch {
+		case c <= ap:
+			paints = append(paints, cm.hexCursor(k, 0, 3, cursorLayer-1))
+			for _, v := range edges(k) {
+				if adj := v.(geom.Key); math.IsInf(cost(k, adj), 1) {
+					blocked[adj] = struct{}{}
+				}
+			}
+		case c <= ap+maxAP:
+			paints = append(paints, cm.hexCursor(k, 1, 3, cursorLayer-1))
+		}
+	}
+	for _, h := range cm.field.Hexes() {
+		if _, ok := blocked[h.Key()]; ok {
+			paints = append(paints, cm.hexCursor(h.Key(), 1, 2, cursorLayer-1))
+		}
+	}
+	return reachable, paints
+}
 
-	if cm.selectedKey == nil {
-		// When nothing is selected, then we have nothing to repaint, skip
-		// straight to the Component removal.
+// targetPaints tints every hex that the skill being selected can target.
+func (cm *CursorManager) targetPaints() []cursorSprite {
+	paints := []cursorSprite{}
+	if cm.skill == nil {
+		return paints
+	}
+	if cm.targets == nil {
+		cm.targets = []geom.Key{}
+		for _, h := range cm.field.Hexes() {
+			if CanTarget(cm.mgr, cm.field, cm.turnToken, cm.skill, h.Key()) {
+				cm.targets = append(cm.targets, h.Key())
+			}
+		}
+	}
+	for _, k := range cm.targets {
+		paints = append(paints, cm.hexCursor(k, 0, 3, cursorLayer-1))
+	}
+	return paints
+}
+
+// deploymentPaints tints the hexes that the local player may deploy to, and
+// outlines every hex of the selected Participant.
+func (cm *CursorManager) deploymentPaints() []cursorSprite {
+	paints := []cursorSprite{}
+	for _, k := range cm.deploying.Zone {
+		paints = append(paints, cm.hexCursor(k, 0, 3, cursorLayer-1))
+	}
+	if cm.deploying.Selected != 0 {
+		obstacle := cm.mgr.Component(cm.deploying.Selected, "Obstacle").(*game.Obstacle)
+		for _, k := range obstacle.Keys() {
+			paints = append(paints, cm.hexCursor(k, 0, 1, cursorLayer))
+		}
+	}
+	return paints
+}
+
+// showPathCost labels the hex at k with the cost of moving there.
+func (cm *CursorManager) showPathCost(k geom.Key, cost int) {
+	e := cm.mgr.AnyTagged(pathCostTag)
+	if e == 0 {
+		e = cm.mgr.NewEntity()
+		cm.mgr.Tag(e, "combat")
+		cm.mgr.Tag(e, pathCostTag)
+	}
+	text := fmt.Sprintf("%d AP", cost)
+	cm.mgr.AddComponent(e, &game.Font{
+		Text: text,
+	})
+	x, y := cm.field.Ktow(k)
+	cm.mgr.AddComponent(e, &game.Position{
+		Center: game.Center{
+			X: x - float64(len(text)*5)/2,
+			Y: y - float64(hexagonHeight)/2,
+		},
+		Layer: participantLayer + 10,
+	})
+}
+
+func (cm *CursorManager) hidePathCost() {
+	for _, e := range cm.mgr.Tagged(pathCostTag) {
+		cm.mgr.DestroyEntity(e)
+	}
+}
+
+func (cm *CursorManager) repaintHighlightedHexes() {
+	paints := []cursorSprite{}
+	cm.hidePathCost()
+
+	if cm.lastState == DeployingState {
+		paints = cm.deploymentPaints()
+	} else if cm.selectedKey == nil {
+		// When nothing is selected, then there is no path to paint, but the
+		// range of movement is still shown.
+		switch cm.lastState {
+		case SelectingPathState:
+			_, paints = cm.moveRangePaints()
+		case SelectingTargetState:
+			paints = cm.targetPaints()
+		}
 	} else if cm.lastState == SelectingPathState {
 		participant := cm.mgr.Component(cm.turnToken, "Participant").(*Participant)
-		pos := cm.mgr.Component(cm.turnToken, "Position").(*game.Position)
+		goal := *cm.selectedKey
 
-		sh := cm.field.At(pos.Center.X, pos.Center.Y)
-		start, goal := sh.Key(), *cm.selectedKey
+		reachable, rangePaints := cm.moveRangePaints()
+		paints = append(paints, rangePaints...)
 
-		cost := CostsFuncFactory(cm.field, cm.mgr, cm.turnToken)
-		edges := EdgeFuncFactory(cm.field)
-		guess := HeuristicFactory(cm.field)
-		steps := graph.NewSearcher(cost, edges, guess).Search(start, goal)
+		steps := reachable.Path(goal)
 		goalHex := cm.field.Get(goal)
 		if goalHex == nil {
 			// TODO: wait, what?
@@ -269,12 +436,17 @@ func (cm *CursorManager) repaintHighlightedHexes() {
 					paints[len(paints)-1].s.Y = hexagonHeight * 2
 				}
 			}
+			if len(steps) > 1 {
+				cm.showPathCost(goal, int(steps[len(steps)-1].Cost))
+			}
 		}
 
 	} else if cm.lastState == SelectingTargetState || cm.lastState == ConfirmingSelectedTargetState {
-		obstacle := cm.mgr.Component(cm.turnToken, "Obstacle").(*game.Obstacle)
-		ok, highlighted := cm.targeting.Execute(*cm.selectedKey, geom.Key{M: obstacle.M, N: obstacle.N})
-		if !ok {
+		paints = append(paints, cm.targetPaints()...)
+		ok, highlighted, origin := executeTargeting(cm.mgr, cm.turnToken, cm.targeting, *cm.selectedKey)
+		visible, obstructions := lineOfSight(cm.mgr, origin, *cm.selectedKey, cm.targeting.Trajectory)
+		matches := matchesFilters(cm.mgr, cm.field, cm.turnToken, *cm.selectedKey, cm.targeting.Selectable.Filters)
+		if !ok || !visible || !matches {
 			// Add a single red cursor on selected hex.
 			x, y := cm.field.Ktow(*cm.selectedKey)
 			paints = append(paints, cursorSprite{
@@ -293,6 +465,25 @@ func (cm *CursorManager) repaintHighlightedHexes() {
 				},
 			})
 
+			// Show what is in the way when the selection is out of sight.
+			for _, k := range obstructions {
+				x, y := cm.field.Ktow(k)
+				paints = append(paints, cursorSprite{
+					s: game.Sprite{
+						Texture: "combat/cursors.png",
+
+						X: hexagonTileWidth, Y: hexagonHeight * 2,
+						W: hexagonTileWidth, H: hexagonHeight,
+					},
+					p: game.Position{
+						Center: game.Center{
+							X: x,
+							Y: y,
+						},
+						Layer: cursorLayer,
+					},
+				})
+			}
 		} else {
 			for _, k := range highlighted {
 				x, y := cm.field.Ktow(k)
@@ -114,6 +114,9 @@ func (ds *damageSystem) handleDamageApplied(event event.Typer) {
 		Amount:     accepted,
 		Reduced:    reduced,
 		DamageType: ty,
+		SkillType:  ev.SkillType,
+		Source:     ev.Source,
+		Hazard:     ev.Hazard,
 	})
 
 	if target.CurrentHealth < 0 {
@@ -0,0 +1,270 @@
+package combat
+
+import (
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+)
+
+// deployRadius is how many hexes from the start of its team that a
+// Participant may be deployed.
+const deployRadius = 2
+
+// deployingState implements the StateContext interface, because the local
+// player needs to know where they can deploy, and which Participant they are
+// moving.
+type deployingState struct {
+	Team  *game.Team
+	Start geom.Key
+
+	// Zone is every hex that Participants of the Team may be deployed to.
+	Zone []geom.Key
+
+	// Selected is the Participant that the next click on the Zone moves.
+	Selected ecs.Entity
+
+	// Dragging is the Participant that the player pressed the mouse on, and
+	// that is dropped wherever the mouse is released, having been picked up
+	// from DragFrom.
+	Dragging ecs.Entity
+	DragFrom geom.Key
+}
+
+// Value satisfies the StateContext interface, and can always return
+// DeployingState.
+func (deployingState) Value() State {
+	return DeployingState
+}
+
+// deploymentZone finds the hexes around start that a team could be deployed
+// to. Other Participants do not take hexes out of the zone, because they can
+// be moved out of the way, and hexes the team already occupies are always part
+// of it.
+func deploymentZone(f *geom.Field, mgr *ecs.World, team *game.Team, start geom.Key) []geom.Key {
+	zone := []geom.Key{}
+	for _, c := range geom.Spiral(start.Cube(), deployRadius) {
+		k := c.Key()
+		if f.Get(k) == nil {
+			continue
+		}
+		blocked := false
+		for _, e := range mgr.Get([]string{"Obstacle"}) {
+			if mgr.Component(e, "Participant") != nil {
+				continue
+			}
+			if mgr.Component(e, "Obstacle").(*game.Obstacle).Occupies(k) {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			zone = append(zone, k)
+		}
+	}
+	for _, e := range mgr.Get([]string{"Participant", "Obstacle", "Team"}) {
+		if mgr.Component(e, "Team").(*game.Team).ID != team.ID {
+			continue
+		}
+		for _, k := range mgr.Component(e, "Obstacle").(*game.Obstacle).Keys() {
+			if !containsKey(zone, k) {
+				zone = append(zone, k)
+			}
+		}
+	}
+	return zone
+}
+
+// canDeploy determines whether the Participant e could be deployed with its
+// anchor at k, if the ignored Entities were out of the way.
+func canDeploy(f *geom.Field, mgr *ecs.World, zone []geom.Key, e ecs.Entity, k geom.Key, ignore ...ecs.Entity) bool {
+	return fitsZone(f, mgr, zone, footprintOf(mgr, e), k, append(ignore, e)...)
+}
+
+// fitsZone determines whether something with footprint would fit within zone
+// with its anchor at k, if the ignored Entities were out of the way.
+func fitsZone(f *geom.Field, mgr *ecs.World, zone []geom.Key, footprint game.Footprint, k geom.Key, ignore ...ecs.Entity) bool {
+	for _, key := range footprint.Keys(k) {
+		if !containsKey(zone, key) {
+			return false
+		}
+	}
+	return !isBlocked(f, k, footprint, mgr, ignore...)
+}
+
+// deploy moves the Participant e so that its anchor is at k.
+func deploy(f *geom.Field, mgr *ecs.World, e ecs.Entity, k geom.Key) {
+	obstacle := mgr.Component(e, "Obstacle").(*game.Obstacle)
+	obstacle.M, obstacle.N = k.M, k.N
+
+	position := mgr.Component(e, "Position").(*game.Position)
+	position.Center.X, position.Center.Y = footprintCenter(f, obstacle.Footprint, k)
+}
+
+// deployedAt finds the Participant of team that occupies k.
+func deployedAt(mgr *ecs.World, team *game.Team, k geom.Key) ecs.Entity {
+	for _, e := range mgr.Get([]string{"Participant", "Obstacle", "Team"}) {
+		if mgr.Component(e, "Team").(*game.Team).ID != team.ID {
+			continue
+		}
+		if mgr.Component(e, "Obstacle").(*game.Obstacle).Occupies(k) {
+			return e
+		}
+	}
+	return 0
+}
+
+// handleDeployPress picks up the Participant of the deploying team under the
+// mouse, so that it can be dragged to where the mouse is released.
+func (cm *Manager) handleDeployPress(ctx *deployingState, x, y float64) {
+	ctx.Dragging = 0
+	h := cm.field.At(x, y)
+	if h == nil {
+		return
+	}
+	ctx.Dragging = deployedAt(cm.mgr, ctx.Team, h.Key())
+	ctx.DragFrom = h.Key()
+}
+
+// handleDeployClick selects a Participant of the deploying team, or moves the
+// selected Participant to the clicked hex, swapping places with any
+// Participant that is already there. A Participant that was dragged to
+// another hex is moved there instead.
+func (cm *Manager) handleDeployClick(x, y float64) {
+	ctx := cm.state.(*deployingState)
+	dragging := ctx.Dragging
+	ctx.Dragging = 0
+	h := cm.field.At(x, y)
+	if h == nil {
+		return
+	}
+	k := h.Key()
+
+	if dragging != 0 && k != ctx.DragFrom {
+		ctx.Selected = dragging
+		if !cm.deploySelected(ctx, k) {
+			ctx.Selected = 0
+		}
+		cm.publishDeployment(ctx)
+		return
+	}
+
+	occupant := deployedAt(cm.mgr, ctx.Team, k)
+	if ctx.Selected == 0 || occupant == ctx.Selected {
+		// Toggle the selection.
+		if occupant == ctx.Selected {
+			occupant = 0
+		}
+		ctx.Selected = occupant
+	} else if !cm.deploySelected(ctx, k) {
+		return
+	}
+
+	cm.publishDeployment(ctx)
+}
+
+// deploySelected moves the selected Participant to k, swapping places with any
+// Participant that is already there, and reports whether it could.
+func (cm *Manager) deploySelected(ctx *deployingState, k geom.Key) bool {
+	occupant := deployedAt(cm.mgr, ctx.Team, k)
+	if occupant == 0 {
+		if !canDeploy(cm.field, cm.mgr, ctx.Zone, ctx.Selected, k) {
+			return false
+		}
+		deploy(cm.field, cm.mgr, ctx.Selected, k)
+		ctx.Selected = 0
+		return true
+	}
+
+	// Swap the selected Participant with the occupant.
+	a := cm.mgr.Component(ctx.Selected, "Obstacle").(*game.Obstacle)
+	b := cm.mgr.Component(occupant, "Obstacle").(*game.Obstacle)
+	ak, bk := geom.Key{M: a.M, N: a.N}, geom.Key{M: b.M, N: b.N}
+	if !canDeploy(cm.field, cm.mgr, ctx.Zone, ctx.Selected, bk, occupant) || !canDeploy(cm.field, cm.mgr, ctx.Zone, occupant, ak, ctx.Selected) {
+		return false
+	}
+	deploy(cm.field, cm.mgr, ctx.Selected, bk)
+	deploy(cm.field, cm.mgr, occupant, ak)
+	// The Participants must not overlap after the swap.
+	if isBlocked(cm.field, bk, a.Footprint, cm.mgr, ctx.Selected) {
+		deploy(cm.field, cm.mgr, ctx.Selected, ak)
+		deploy(cm.field, cm.mgr, occupant, bk)
+		return false
+	}
+	ctx.Selected = 0
+	return true
+}
+
+// publishDeployment lets the cursors know that the deployment has changed.
+func (cm *Manager) publishDeployment(ctx *deployingState) {
+	var selected *geom.Key
+	if ctx.Selected != 0 {
+		o := cm.mgr.Component(ctx.Selected, "Obstacle").(*game.Obstacle)
+		selected = &geom.Key{M: o.M, N: o.N}
+	}
+	cm.bus.Publish(&DifferentHexSelected{
+		K:       selected,
+		Context: ctx,
+	})
+	cm.handleParticipantMoving(nil)
+}
+
+func (cm *Manager) handleDeploymentConfirmed(t event.Typer) {
+	ctx, ok := cm.state.(*deployingState)
+	if !ok {
+		return
+	}
+	ev := t.(*DeploymentConfirmed)
+	if ev.SaveFormation {
+		for _, e := range cm.squads {
+			saveFormation(cm.mgr, e, ctx.Team, ctx.Start)
+		}
+	}
+	cm.setState(PreparingState)
+}
+
+// saveFormation stores the arrangement of the Participants of team on the
+// Squad so that the next combat can begin with the same arrangement.
+func saveFormation(mgr *ecs.World, squadEntity ecs.Entity, team *game.Team, start geom.Key) {
+	squad := mgr.Component(squadEntity, "Squad").(*game.Squad)
+	formation := map[ecs.Entity]geom.Cube{}
+	for _, e := range mgr.Get([]string{"Participant", "Obstacle", "Team"}) {
+		if mgr.Component(e, "Team").(*game.Team).ID != team.ID {
+			continue
+		}
+		participant := mgr.Component(e, "Participant").(*Participant)
+		if !containsEntity(squad.Members, participant.Character) {
+			continue
+		}
+		o := mgr.Component(e, "Obstacle").(*game.Obstacle)
+		formation[participant.Character] = geom.Key{M: o.M, N: o.N}.Cube().Sub(start.Cube())
+	}
+	if len(formation) == 0 {
+		return
+	}
+	squad.Formation = formation
+}
+
+// formationStart finds where the Character e should be deployed according to
+// the Formation of its Squad, if the Formation places it somewhere it fits
+// within the deployment zone of team around start.
+func formationStart(f *geom.Field, mgr *ecs.World, team *game.Team, squad *game.Squad, e ecs.Entity, start geom.Key, footprint game.Footprint) (*geom.Hex, bool) {
+	offset, ok := squad.Formation[e]
+	if !ok {
+		return nil, false
+	}
+	h := f.Get(start.Cube().Add(offset).Key())
+	if h == nil || !fitsZone(f, mgr, deploymentZone(f, mgr, team, start), footprint, h.Key()) {
+		return nil, false
+	}
+	return h, true
+}
+
+func containsEntity(entities []ecs.Entity, e ecs.Entity) bool {
+	for _, entity := range entities {
+		if entity == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+)
+
+func TestDeployment(t *testing.T) {
+	field := newTestField(t, 8, 8)
+	mgr := ecs.NewWorld()
+	team := &game.Team{ID: 1}
+	start := geom.Key{M: 3, N: 3}
+
+	tree := mgr.NewEntity()
+	mgr.AddComponent(tree, &game.Obstacle{M: 3, N: 1, ObstacleType: game.TreeObstacle})
+
+	participate := func(k geom.Key, footprint game.Footprint) ecs.Entity {
+		char := mgr.NewEntity()
+		e := mgr.NewEntity()
+		mgr.AddComponent(e, &Participant{Character: char, Status: Alive})
+		mgr.AddComponent(e, team)
+		mgr.AddComponent(e, &game.Obstacle{M: k.M, N: k.N, ObstacleType: game.CharacterObstacle, Footprint: footprint})
+		x, y := footprintCenter(field, footprint, k)
+		mgr.AddComponent(e, &game.Position{Center: game.Center{X: x, Y: y}})
+		return e
+	}
+	a := participate(geom.Key{M: 3, N: 3}, game.SmallFootprint)
+	b := participate(geom.Key{M: 3, N: 4}, game.SmallFootprint)
+	// c starts outside the zone.
+	c := participate(geom.Key{M: 7, N: 7}, game.SmallFootprint)
+
+	zone := deploymentZone(field, mgr, team, start)
+	if containsKey(zone, geom.Key{M: 3, N: 1}) {
+		t.Errorf("zone contains the tree")
+	}
+	if !containsKey(zone, geom.Key{M: 7, N: 7}) {
+		t.Errorf("zone is missing a hex the team occupies")
+	}
+	if want := len(geom.Spiral(start.Cube(), deployRadius)); len(zone) != want {
+		// One hex lost to the tree and one gained from c.
+		t.Errorf("want %d hexes in the zone, got %d", want, len(zone))
+	}
+
+	ctx := &deployingState{Team: team, Start: start, Zone: zone}
+	cm := &Manager{
+		mgr:   mgr,
+		bus:   &event.Bus{},
+		field: field,
+		state: ctx,
+	}
+	click := func(k geom.Key) {
+		x, y := field.Ktow(k)
+		cm.handleDeployClick(x, y)
+	}
+	at := func(e ecs.Entity) geom.Key {
+		o := mgr.Component(e, "Obstacle").(*game.Obstacle)
+		return geom.Key{M: o.M, N: o.N}
+	}
+
+	// Move c into the zone.
+	click(geom.Key{M: 7, N: 7})
+	if ctx.Selected != c {
+		t.Fatalf("want c selected, got %d", ctx.Selected)
+	}
+	click(geom.Key{M: 2, N: 2})
+	if got := at(c); got != (geom.Key{M: 2, N: 2}) {
+		t.Errorf("want c at 2,2, got %v", got)
+	}
+	if ctx.Selected != 0 {
+		t.Errorf("want nothing selected after moving")
+	}
+
+	// The tree is not part of the zone.
+	click(geom.Key{M: 2, N: 2})
+	click(geom.Key{M: 3, N: 1})
+	if got := at(c); got != (geom.Key{M: 2, N: 2}) {
+		t.Errorf("want c to stay at 2,2, got %v", got)
+	}
+
+	// Swap a and b.
+	click(geom.Key{M: 2, N: 2})
+	click(geom.Key{M: 3, N: 3})
+	click(geom.Key{M: 3, N: 4})
+	if at(a) != (geom.Key{M: 3, N: 4}) || at(b) != (geom.Key{M: 3, N: 3}) {
+		t.Errorf("want a and b swapped, got a at %v and b at %v", at(a), at(b))
+	}
+
+	// a can be dragged back to where it began, swapping with b.
+	press := func(k geom.Key) {
+		x, y := field.Ktow(k)
+		cm.handleDeployPress(ctx, x, y)
+	}
+	press(geom.Key{M: 3, N: 4})
+	click(geom.Key{M: 3, N: 3})
+	if at(a) != (geom.Key{M: 3, N: 3}) || at(b) != (geom.Key{M: 3, N: 4}) {
+		t.Errorf("want a dragged back, got a at %v and b at %v", at(a), at(b))
+	}
+	if ctx.Selected != 0 {
+		t.Errorf("want nothing selected after dragging")
+	}
+
+	// A drag to the tree is dropped where it began.
+	press(geom.Key{M: 3, N: 3})
+	click(geom.Key{M: 3, N: 1})
+	if at(a) != (geom.Key{M: 3, N: 3}) || ctx.Selected != 0 {
+		t.Errorf("want a to stay at 3,3 unselected, got %v", at(a))
+	}
+
+	// Pressing and releasing on the same hex is a click.
+	press(geom.Key{M: 3, N: 3})
+	click(geom.Key{M: 3, N: 3})
+	if ctx.Selected != a {
+		t.Errorf("want a selected, got %d", ctx.Selected)
+	}
+	click(geom.Key{M: 3, N: 3})
+
+	// The arrangement can be saved as a Formation and restored.
+	squadEntity := mgr.NewEntity()
+	members := []ecs.Entity{}
+	for _, e := range []ecs.Entity{a, b, c} {
+		members = append(members, mgr.Component(e, "Participant").(*Participant).Character)
+	}
+	mgr.AddComponent(squadEntity, &game.Squad{Members: members})
+	saveFormation(mgr, squadEntity, team, start)
+	squad := mgr.Component(squadEntity, "Squad").(*game.Squad)
+	if len(squad.Formation) != 3 {
+		t.Fatalf("want 3 members in the formation, got %d", len(squad.Formation))
+	}
+
+	// Elsewhere on the field, the formation keeps its shape.
+	for _, e := range []ecs.Entity{a, b, c} {
+		mgr.RemoveComponent(e, &game.Obstacle{})
+	}
+	elsewhere := geom.Key{M: 4, N: 5}
+	h, ok := formationStart(field, mgr, team, squad, members[2], elsewhere, game.SmallFootprint)
+	if !ok {
+		t.Fatalf("want a formation start for c")
+	}
+	if want := elsewhere.Cube().Add(geom.Key{M: 2, N: 2}.Cube().Sub(start.Cube())).Key(); h.Key() != want {
+		t.Errorf("want c at %v, got %v", want, h.Key())
+	}
+
+	// A Formation that no longer fits in the zone is not used.
+	squad.Formation[members[2]] = geom.Key{M: 0, N: 0}.Cube().Sub(elsewhere.Cube())
+	if _, ok := formationStart(field, mgr, team, squad, members[2], elsewhere, game.SmallFootprint); ok {
+		t.Errorf("want no formation start outside the zone")
+	}
+	squad.Formation[members[2]] = geom.Key{M: 3, N: 1}.Cube().Sub(elsewhere.Cube())
+	if _, ok := formationStart(field, mgr, team, squad, members[2], elsewhere, game.SmallFootprint); ok {
+		t.Errorf("want no formation start on the tree")
+	}
+}
@@ -0,0 +1,140 @@
+package combat
+
+import (
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+)
+
+// escapeCost is the ActionPoints it costs to attempt an escape, whether it
+// succeeds or not.
+const escapeCost = 20
+
+// zoneOfControl is how many hexes from its footprint a Participant hinders
+// the escape of its enemies.
+const zoneOfControl = 1
+
+// The limits of the chance to escape while enemies are near.
+const (
+	minEscapeChance = 0.05
+	maxEscapeChance = 0.95
+)
+
+// canEscapeFrom determines whether the Participant e is positioned to escape,
+// because its footprint touches the edge of the field or an escape hex. Every
+// hex of the field has Terrain, so the edge is wherever the Terrain stops.
+func canEscapeFrom(mgr *ecs.World, e ecs.Entity) bool {
+	obstacle, ok := mgr.Component(e, "Obstacle").(*game.Obstacle)
+	if !ok {
+		return false
+	}
+	terrain := terrainByKey(mgr)
+	escape := func(k geom.Key) bool {
+		t, ok := terrain[k]
+		return ok && t.Escape
+	}
+	for _, k := range obstacle.Keys() {
+		if escape(k) {
+			return true
+		}
+		for n := range k.Neighbors() {
+			if _, ok := terrain[n]; !ok || escape(n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// escapeChance is the chance that the Participant e succeeds in escaping. It
+// cannot be stopped when no enemies are near, and otherwise its Agility is
+// pitted against the Agility of every enemy whose zone of control it is in.
+func escapeChance(mgr *ecs.World, e ecs.Entity) float64 {
+	participant := mgr.Component(e, "Participant").(*Participant)
+	team := mgr.Component(e, "Team").(*game.Team)
+	obstacle, ok := mgr.Component(e, "Obstacle").(*game.Obstacle)
+	if !ok {
+		return 0
+	}
+
+	hindrance := 0
+	for _, other := range mgr.Get([]string{"Participant", "Team", "Obstacle"}) {
+		enemy := mgr.Component(other, "Participant").(*Participant)
+		if enemy.Status != Alive || mgr.Component(other, "Team").(*game.Team).ID == team.ID {
+			continue
+		}
+		if footprintDistance(obstacle, mgr.Component(other, "Obstacle").(*game.Obstacle)) > zoneOfControl {
+			continue
+		}
+		hindrance += enemy.Agility
+	}
+	if hindrance == 0 {
+		return 1
+	}
+
+	chance := float64(participant.Agility) / float64(participant.Agility+hindrance)
+	if chance < minEscapeChance {
+		return minEscapeChance
+	}
+	if chance > maxEscapeChance {
+		return maxEscapeChance
+	}
+	return chance
+}
+
+// footprintDistance is the fewest hexes between the footprints of two
+// Obstacles.
+func footprintDistance(a, b *game.Obstacle) int {
+	return keysDistance(a.Keys(), b.Keys())
+}
+
+// keysDistance is the fewest hexes between any hex of a and any hex of b.
+func keysDistance(a, b []geom.Key) int {
+	result := -1
+	for _, ak := range a {
+		for _, bk := range b {
+			if d := ak.Cube().Distance(bk.Cube()); result < 0 || d < result {
+				result = d
+			}
+		}
+	}
+	return result
+}
+
+// leavesFallenBehind determines whether the escape of e would leave
+// Knocked Down members of its team behind, because nobody else on the team
+// remains to fight for them.
+func leavesFallenBehind(mgr *ecs.World, e ecs.Entity) bool {
+	team := mgr.Component(e, "Team").(*game.Team)
+	fallen := false
+	for _, other := range mgr.Get([]string{"Participant", "Team"}) {
+		if other == e || mgr.Component(other, "Team").(*game.Team).ID != team.ID {
+			continue
+		}
+		switch mgr.Component(other, "Participant").(*Participant).Status {
+		case Alive:
+			return false
+		case KnockedDown:
+			fallen = true
+		}
+	}
+	return fallen
+}
+
+// leftBehind finds the Knocked Down Characters of the Squads that escaped.
+func (cm *Manager) leftBehind(results map[ecs.Entity]game.CombatResult) []ecs.Entity {
+	result := []ecs.Entity{}
+	for _, squadEntity := range cm.squads {
+		if results[squadEntity] != game.Escaped {
+			continue
+		}
+		squad := cm.mgr.Component(squadEntity, "Squad").(*game.Squad)
+		for _, e := range cm.mgr.Get([]string{"Participant"}) {
+			participant := cm.mgr.Component(e, "Participant").(*Participant)
+			if participant.Status == KnockedDown && containsEntity(squad.Members, participant.Character) {
+				result = append(result, participant.Character)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+)
+
+func TestEscape(t *testing.T) {
+	mgr := ecs.NewWorld()
+	for m := 0; m < 8; m++ {
+		for n := 0; n < 8; n++ {
+			mgr.AddComponent(mgr.NewEntity(), &game.Terrain{M: m, N: n, Escape: m == 5 && n == 5})
+		}
+	}
+	participate := func(team int64, m, n int, agility int) ecs.Entity {
+		e := mgr.NewEntity()
+		mgr.AddComponent(e, &Participant{Status: Alive, Agility: agility})
+		mgr.AddComponent(e, &game.Team{ID: team})
+		mgr.AddComponent(e, &game.Obstacle{M: m, N: n, ObstacleType: game.CharacterObstacle, Footprint: game.SmallFootprint})
+		return e
+	}
+
+	edge := participate(1, 0, 3, 10)
+	middle := participate(1, 3, 3, 10)
+	nearExit := participate(1, 4, 5, 10)
+
+	for _, tc := range []struct {
+		name string
+		e    ecs.Entity
+		want bool
+	}{
+		{"edge", edge, true},
+		{"middle", middle, false},
+		{"escape hex", nearExit, true},
+	} {
+		if got := canEscapeFrom(mgr, tc.e); got != tc.want {
+			t.Errorf("%s: want %t, got %t", tc.name, tc.want, got)
+		}
+	}
+
+	if got := escapeChance(mgr, middle); got != 1 {
+		t.Errorf("want certain escape without enemies, got %f", got)
+	}
+	participate(2, 7, 0, 90)
+	if got := escapeChance(mgr, middle); got != 1 {
+		t.Errorf("want distant enemies ignored, got %f", got)
+	}
+	participate(2, 3, 2, 10)
+	if got := escapeChance(mgr, middle); got != 0.5 {
+		t.Errorf("want even odds against an equal enemy, got %f", got)
+	}
+	participate(2, 3, 4, 1000)
+	if got := escapeChance(mgr, middle); got != minEscapeChance {
+		t.Errorf("want the minimum chance when surrounded, got %f", got)
+	}
+
+	if leavesFallenBehind(mgr, middle) {
+		t.Errorf("want nobody left behind while teammates are standing")
+	}
+	mgr.Component(edge, "Participant").(*Participant).Status = KnockedDown
+	mgr.Component(nearExit, "Participant").(*Participant).Status = Escaped
+	if !leavesFallenBehind(mgr, middle) {
+		t.Errorf("want the fallen left behind by the last to escape")
+	}
+}
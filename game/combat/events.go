@@ -97,6 +97,119 @@ func (ParticipantMoving) Type() event.Type {
 	return "combat.ParticipantMoving"
 }
 
+// ParticipantLeavingHex occurs when a moving Participant is about to leave a
+// hex for the next hex of its path.
+type ParticipantLeavingHex struct {
+	Entity       ecs.Entity
+	FromX, FromY float64
+	ToX, ToY     float64
+}
+
+// Type of the Event.
+func (ParticipantLeavingHex) Type() event.Type {
+	return "combat.ParticipantLeavingHex"
+}
+
+// ReactionConcluded occurs when a skill used in reaction to something has
+// finished executing.
+type ReactionConcluded struct {
+	Entity ecs.Entity
+}
+
+// Type of the Event.
+func (ReactionConcluded) Type() event.Type {
+	return "combat.ReactionConcluded"
+}
+
+// DeploymentConfirmed occurs when the local player has finished arranging
+// their squad, and the combat should begin. When SaveFormation is set, the
+// arrangement is remembered for the next combat.
+type DeploymentConfirmed struct {
+	SaveFormation bool
+}
+
+// Type of the Event.
+func (DeploymentConfirmed) Type() event.Type {
+	return "combat.DeploymentConfirmed"
+}
+
+// SpeedRequested occurs when the player asks for combat to play out at a
+// different Speed, or to switch instant mode on or off.
+type SpeedRequested struct {
+	Speed   Speed
+	Instant bool
+}
+
+// Type of the Event.
+func (SpeedRequested) Type() event.Type {
+	return "combat.SpeedRequested"
+}
+
+// SpeedChanged occurs when the Speed of combat or instant mode has changed.
+type SpeedChanged struct {
+	Speed   Speed
+	Instant bool
+}
+
+// Type of the Event.
+func (SpeedChanged) Type() event.Type {
+	return "combat.SpeedChanged"
+}
+
+// UndoRequested occurs when the player asks to take back the last move of the
+// Participant whose turn it is.
+type UndoRequested struct{}
+
+// Type of the Event.
+func (UndoRequested) Type() event.Type {
+	return "combat.UndoRequested"
+}
+
+// UndoChanged occurs when the number of moves that the Participant whose turn
+// it is could undo has changed.
+type UndoChanged struct {
+	Entity ecs.Entity
+	Moves  int
+}
+
+// Type of the Event.
+func (UndoChanged) Type() event.Type {
+	return "combat.UndoChanged"
+}
+
+// CombatSummarised occurs when a combat is over, and its Summary is ready to
+// present to the player.
+type CombatSummarised struct {
+	Summary *Summary
+}
+
+// Type of the Event.
+func (CombatSummarised) Type() event.Type {
+	return "combat.CombatSummarised"
+}
+
+// SummaryDismissed occurs when the player has finished reviewing the Summary
+// of a combat.
+type SummaryDismissed struct{}
+
+// Type of the Event.
+func (SummaryDismissed) Type() event.Type {
+	return "combat.SummaryDismissed"
+}
+
+// ParticipantEnteredHex occurs when a moving Participant has arrived at the
+// next hex of its path, from the world coordinates FromX,FromY to ToX,ToY.
+type ParticipantEnteredHex struct {
+	Entity       ecs.Entity
+	FromX, FromY float64
+	ToX, ToY     float64
+}
+
+// Type of the Event.
+func (ParticipantEnteredHex) Type() event.Type {
+	return "combat.ParticipantEnteredHex"
+}
+
 // ParticipantMovementConcluded occurs when a Character has finished their movement.
 type ParticipantMovementConcluded struct {
 	Entity ecs.Entity
@@ -117,6 +230,18 @@ func (AttemptingEscape) Type() event.Type {
 	return "combat.AttemptingEscape"
 }
 
+// EscapeFailed occurs when a character attempted to escape from combat, but
+// was stopped by the enemies around it.
+type EscapeFailed struct {
+	Entity ecs.Entity
+	Chance float64
+}
+
+// Type of the Event.
+func (EscapeFailed) Type() event.Type {
+	return "combat.EscapeFailed"
+}
+
 // CharacterCelebrating occurs when a character has something to shout about.
 type CharacterCelebrating struct {
 	Entity ecs.Entity
@@ -132,6 +257,10 @@ type UsingSkill struct {
 	User     ecs.Entity
 	Skill    skill.ID
 	Selected *geom.Hex
+
+	// Reaction is set when the skill is being used out of turn in reaction
+	// to something.
+	Reaction bool
 }
 
 // Type of the Event.
@@ -158,6 +287,12 @@ type DamageApplied struct {
 	Target     ecs.Entity
 	DamageType game.DamageType
 	SkillType  skill.Classification
+
+	// Source is the Participant that dealt the damage, if any did.
+	Source ecs.Entity
+
+	// Hazard is the Terrain Hazard that dealt the damage, if one did.
+	Hazard game.HazardType
 }
 
 // Type of the Event.
@@ -172,6 +307,13 @@ type DamageAccepted struct {
 	Amount     int
 	Reduced    int
 	DamageType game.DamageType
+	SkillType  skill.Classification
+
+	// Source is the Participant that dealt the damage, if any did.
+	Source ecs.Entity
+
+	// Hazard is the Terrain Hazard that dealt the damage, if one did.
+	Hazard game.HazardType
 }
 
 // Type of the Event.
@@ -226,8 +368,16 @@ func (ParticipantDefiled) Type() event.Type {
 type CharacterEnteredCombat struct {
 	Level      int
 	Profession string
-	Team       *game.Team
-	At         geom.Key
+
+	// Team is the team the new Participant fights for. A nil Team puts it on
+	// a new team of its own.
+	Team *game.Team
+	At   geom.Key
+
+	// Summoner is the Participant that summoned the new Participant, and
+	// Lifetime is how long the summons lasts.
+	Summoner ecs.Entity
+	Lifetime skill.Lifetime
 }
 
 // Type of the Event.
@@ -0,0 +1,73 @@
+package combat
+
+import (
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+	"github.com/griffithsh/squads/targeting"
+)
+
+// footprintOffset is the distance from the center of the anchor of a
+// Footprint to the center of the whole Footprint. It is the same wherever the
+// Footprint is anchored.
+func footprintOffset(field *geom.Field, footprint game.Footprint) (float64, float64) {
+	keys := footprint.Keys(geom.Key{})
+	var sumX, sumY float64
+	for _, k := range keys {
+		x, y := field.Ktow(k)
+		sumX += x
+		sumY += y
+	}
+	return sumX / float64(len(keys)), sumY / float64(len(keys))
+}
+
+// footprintCenter finds the world coordinates of the center of a Footprint
+// anchored at anchor. Participants with larger Footprints are Positioned here.
+func footprintCenter(field *geom.Field, footprint game.Footprint, anchor geom.Key) (float64, float64) {
+	x, y := field.Ktow(anchor)
+	dx, dy := footprintOffset(field, footprint)
+	return x + dx, y + dy
+}
+
+// footprintAnchor is the inverse of footprintCenter, finding the anchor of a
+// Footprint that is centered at x,y.
+func footprintAnchor(field *geom.Field, footprint game.Footprint, x, y float64) geom.Key {
+	dx, dy := footprintOffset(field, footprint)
+	return field.Wtok(x-dx, y-dy)
+}
+
+// footprintOf returns the Footprint of an Entity, which is the Footprint of its
+// Obstacle if it has one.
+func footprintOf(mgr *ecs.World, e ecs.Entity) game.Footprint {
+	if o, ok := mgr.Component(e, "Obstacle").(*game.Obstacle); ok {
+		return o.Footprint
+	}
+	return game.SmallFootprint
+}
+
+// executeTargeting executes a skill's targeting Rule from every hex that the
+// user occupies, so that users with large Footprints can reach from any part
+// of themselves. It prefers hexes with a line of sight to the selection, and
+// returns the anchor when the selection is not valid from any hex.
+func executeTargeting(mgr *ecs.World, user ecs.Entity, rule *targeting.Rule, selected geom.Key) (selectable bool, paints []geom.Key, origin geom.Key) {
+	obstacle := mgr.Component(user, "Obstacle").(*game.Obstacle)
+	facer, _ := mgr.Component(user, "Facer").(*game.Facer)
+	facing := game.FacingOf(facer)
+
+	keys := obstacle.Keys()
+	origin = keys[0]
+	_, paints = rule.Execute(selected, origin, facing)
+	for _, k := range keys {
+		ok, p := rule.Execute(selected, k, facing)
+		if !ok {
+			continue
+		}
+		if !selectable {
+			selectable, paints, origin = true, p, k
+		}
+		if visible, _ := lineOfSight(mgr, k, selected, rule.Trajectory); visible {
+			return true, p, k
+		}
+	}
+	return selectable, paints, origin
+}
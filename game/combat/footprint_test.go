@@ -0,0 +1,123 @@
+package combat
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+	"github.com/griffithsh/squads/targeting"
+)
+
+func newTestField(t *testing.T, w, h int) *geom.Field {
+	t.Helper()
+	field := geom.NewField(hexagonBodyWidth, hexagonWingWidth, hexagonHeight)
+	keys := []geom.Key{}
+	for m := 0; m < w; m++ {
+		for n := 0; n < h; n++ {
+			keys = append(keys, geom.Key{M: m, N: n})
+		}
+	}
+	if err := field.Load(keys); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return field
+}
+
+func TestFootprintAnchor(t *testing.T) {
+	field := newTestField(t, 8, 8)
+	for _, footprint := range []game.Footprint{game.SmallFootprint, game.MediumFootprint, game.LargeFootprint} {
+		for _, anchor := range []geom.Key{{M: 2, N: 2}, {M: 3, N: 2}, {M: 4, N: 5}, {M: 5, N: 5}} {
+			t.Run(fmt.Sprintf("%v-%v", footprint, anchor), func(t *testing.T) {
+				x, y := footprintCenter(field, footprint, anchor)
+				if got := footprintAnchor(field, footprint, x, y); got != anchor {
+					t.Errorf("want anchor %v, got %v", anchor, got)
+				}
+			})
+		}
+	}
+
+	// The center of a Large Footprint is the center of its anchor.
+	x, y := footprintCenter(field, game.LargeFootprint, geom.Key{M: 3, N: 3})
+	wx, wy := field.Ktow(geom.Key{M: 3, N: 3})
+	if math.Abs(x-wx) > 1e-9 || math.Abs(y-wy) > 1e-9 {
+		t.Errorf("want %f,%f, got %f,%f", wx, wy, x, y)
+	}
+}
+
+func TestFootprintPathing(t *testing.T) {
+	field := newTestField(t, 8, 8)
+	mgr := ecs.NewWorld()
+	wolf := mgr.NewEntity()
+	mgr.AddComponent(wolf, &game.Obstacle{M: 2, N: 2, ObstacleType: game.CharacterObstacle, Footprint: game.MediumFootprint})
+	tree := mgr.NewEntity()
+	// The tree is SE of 4,4, so the wolf cannot anchor itself at 4,4.
+	mgr.AddComponent(tree, &game.Obstacle{M: 5, N: 4, ObstacleType: game.TreeObstacle})
+	// Mud under two hexes of a Footprint is no slower than mud under one.
+	for _, k := range []geom.Key{{M: 7, N: 5}, {M: 7, N: 6}} {
+		mgr.AddComponent(mgr.NewEntity(), &game.Obstacle{M: k.M, N: k.N, ObstacleType: game.MudObstacle})
+	}
+
+	cost := CostsFuncFactory(field, mgr, wolf)
+	for _, tc := range []struct {
+		to   geom.Key
+		want float64
+	}{
+		{geom.Key{M: 2, N: 3}, 10},
+		{geom.Key{M: 4, N: 4}, math.Inf(0)},
+		{geom.Key{M: 5, N: 4}, math.Inf(0)},
+		{geom.Key{M: 5, N: 5}, 10},
+		{geom.Key{M: 6, N: 6}, 20},
+		// The NE and SE hexes of the Footprint would be off the Field.
+		{geom.Key{M: 7, N: 3}, math.Inf(0)},
+	} {
+		if got := cost(geom.Key{}, tc.to); got != tc.want {
+			t.Errorf("%v: want %f, got %f", tc.to, tc.want, got)
+		}
+	}
+
+	// Other Participants cannot be placed over any hex of the wolf.
+	for _, k := range []geom.Key{{M: 2, N: 2}, {M: 3, N: 1}, {M: 3, N: 2}} {
+		if !isBlocked(field, k, game.SmallFootprint, mgr) {
+			t.Errorf("want %v blocked by the wolf", k)
+		}
+	}
+	if isBlocked(field, geom.Key{M: 1, N: 1}, game.SmallFootprint, mgr) {
+		t.Errorf("want 1,1 not blocked")
+	}
+	// Another wolf cannot fit where its NE hex would overlap.
+	if !isBlocked(field, geom.Key{M: 2, N: 3}, game.MediumFootprint, mgr) {
+		t.Errorf("want 2,3 blocked for a second wolf")
+	}
+}
+
+func TestFootprintTargeting(t *testing.T) {
+	mgr := ecs.NewWorld()
+	user := mgr.NewEntity()
+	mgr.AddComponent(user, &game.Obstacle{M: 2, N: 2, ObstacleType: game.CharacterObstacle, Footprint: game.MediumFootprint})
+	mgr.AddComponent(user, &game.Facer{Face: geom.S})
+
+	melee := targeting.Rule{
+		Selectable: targeting.Selectable{Type: targeting.SelectWithin, MinRange: 1, MaxRange: 1},
+		Brush:      targeting.Brush{Type: targeting.SingleHex},
+	}
+
+	// 4,2 is adjacent to the NE hex of the Footprint but not to its anchor.
+	selected := geom.Key{M: 4, N: 2}
+	ok, paints, origin := executeTargeting(mgr, user, &melee, selected)
+	if !ok {
+		t.Fatalf("want %v reachable from the Footprint", selected)
+	}
+	if origin == (geom.Key{M: 2, N: 2}) {
+		t.Errorf("want reach from a hex other than the anchor")
+	}
+	if len(paints) != 1 || paints[0] != selected {
+		t.Errorf("want %v painted, got %v", selected, paints)
+	}
+
+	if ok, _, _ := executeTargeting(mgr, user, &melee, geom.Key{M: 6, N: 2}); ok {
+		t.Errorf("want 6,2 out of reach")
+	}
+}
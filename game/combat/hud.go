@@ -3,7 +3,6 @@ package combat
 import (
 	"fmt"
 	"os"
-	"sort"
 	"strconv"
 	"time"
 
@@ -58,6 +57,24 @@ type HUD struct {
 	centerX, centerY float64 // center of the game's window, or half the width and height.
 	lastCombatState  State
 
+	// confirming is the target being confirmed when the combat is in
+	// ConfirmingSelectedTargetState.
+	confirming *confirmingSelectedTargetState
+
+	// previewing is the skill whose cost the turn order timeline includes,
+	// either because it is hovered, or because its target is being chosen.
+	previewing skill.ID
+	hovering   skill.ID
+
+	// fleeing is set when the player has been warned that fleeing would leave
+	// the fallen behind, so that fleeing again confirms it.
+	fleeing bool
+
+	// escapable caches whether the Participant whose turn it is stands where
+	// it could escape from. It is nil until it is worked out again after the
+	// Participant moves or the turn changes.
+	escapable *bool
+
 	// Whose turn is it?
 	turnToken ecs.Entity
 
@@ -68,10 +85,29 @@ type HUD struct {
 	uiEntity             ecs.Entity
 	turnQueueUIComponent *ui.UI
 	fullUIComponent      *ui.UI
+	deployUIComponent    *ui.UI
+	summaryUIComponent   *ui.UI
+
+	// summary of the combat, once it is over and until it is dismissed.
+	summary *Summary
+
+	log            *CombatLog
+	logEntity      ecs.Entity
+	logUIComponent *ui.UI
+	// logScroll is how many entries back from the newest the log panel is
+	// scrolled.
+	logScroll int
+
+	speed   Speed
+	instant bool
+
+	// undoable is how many moves the Participant whose turn it is could take
+	// back.
+	undoable int
 }
 
 // NewHUD constructs a HUD.
-func NewHUD(mgr *ecs.World, bus *event.Bus, screenX int, screenY int, archive SkillArchive) *HUD {
+func NewHUD(mgr *ecs.World, bus *event.Bus, screenX int, screenY int, archive SkillArchive, log *CombatLog) *HUD {
 	makeUI := func(file string) *ui.UI {
 		f, err := os.Open(file)
 		if err != nil {
@@ -94,6 +130,12 @@ func NewHUD(mgr *ecs.World, bus *event.Bus, screenX int, screenY int, archive Sk
 		uiEntity:             mgr.NewEntity(),
 		fullUIComponent:      makeUI("game/combat/ui.xml"),
 		turnQueueUIComponent: makeUI("game/combat/turnQueue.xml"),
+		deployUIComponent:    makeUI("game/combat/deploy.xml"),
+		summaryUIComponent:   makeUI("game/combat/summary.xml"),
+
+		log:            log,
+		logEntity:      mgr.NewEntity(),
+		logUIComponent: makeUI("game/combat/log.xml"),
 	}
 
 	bus.Subscribe(game.WindowSizeChanged{}.Type(), hud.handleWindowSizeChanged)
@@ -101,6 +143,11 @@ func NewHUD(mgr *ecs.World, bus *event.Bus, screenX int, screenY int, archive Sk
 	bus.Subscribe(ParticipantTurnChanged{}.Type(), hud.handleParticipantTurnChanged)
 	bus.Subscribe(DamageAccepted{}.Type(), hud.handleDamageAccepted)
 	bus.Subscribe(DamageFailed{}.Type(), hud.handleDamageFailed)
+	bus.Subscribe(EscapeFailed{}.Type(), hud.handleEscapeFailed)
+	bus.Subscribe(CombatSummarised{}.Type(), hud.handleCombatSummarised)
+	bus.Subscribe(SpeedChanged{}.Type(), hud.handleSpeedChanged)
+	bus.Subscribe(UndoChanged{}.Type(), hud.handleUndoChanged)
+	bus.Subscribe(ParticipantMovementConcluded{}.Type(), hud.handleParticipantMovementConcluded)
 
 	return &hud
 }
@@ -119,6 +166,9 @@ func (hud *HUD) Enable() {
 // the hud until Enable() is called.
 func (hud *HUD) Disable() {
 	hud.hideTimePassingIcon()
+	hud.mgr.RemoveComponent(hud.logEntity, &ui.UI{})
+	hud.logScroll = 0
+	hud.summary = nil
 
 	hud.dormant = true
 }
@@ -146,6 +196,17 @@ func (hud *HUD) handleCombatStateTransition(ev event.Typer) {
 
 	cst := ev.(*StateTransition)
 	hud.lastCombatState = cst.New.Value()
+	hud.confirming, _ = cst.New.(*confirmingSelectedTargetState)
+	hud.hovering = ""
+	hud.fleeing = false
+	switch ctx := cst.New.(type) {
+	case *selectingTargetState:
+		hud.previewing = ctx.Skill
+	case *confirmingSelectedTargetState:
+		hud.previewing = ctx.Skill
+	default:
+		hud.previewing = ""
+	}
 
 	if cst.New == PreparingState {
 		hud.showTimePassingIcon()
@@ -157,6 +218,22 @@ func (hud *HUD) handleCombatStateTransition(ev event.Typer) {
 func (hud *HUD) handleParticipantTurnChanged(t event.Typer) {
 	ev := t.(*ParticipantTurnChanged)
 	hud.turnToken = ev.Entity
+	hud.fleeing = false
+	hud.escapable = nil
+}
+
+func (hud *HUD) handleParticipantMovementConcluded(event.Typer) {
+	hud.escapable = nil
+}
+
+// canEscape determines whether the Participant whose turn it is could escape
+// from where it stands.
+func (hud *HUD) canEscape() bool {
+	if hud.escapable == nil {
+		escapable := canEscapeFrom(hud.mgr, hud.turnToken)
+		hud.escapable = &escapable
+	}
+	return *hud.escapable
 }
 
 func (hud *HUD) makeDamageOutcome(target ecs.Entity, text string) {
@@ -195,10 +272,80 @@ func (hud *HUD) handleDamageFailed(t event.Typer) {
 	hud.makeDamageOutcome(ev.Target, text)
 }
 
+func (hud *HUD) handleEscapeFailed(t event.Typer) {
+	ev := t.(*EscapeFailed)
+	hud.makeDamageOutcome(ev.Entity, "Caught!")
+}
+
+func (hud *HUD) handleSpeedChanged(t event.Typer) {
+	ev := t.(*SpeedChanged)
+	hud.speed, hud.instant = ev.Speed, ev.Instant
+}
+
+func (hud *HUD) handleUndoChanged(t event.Typer) {
+	ev := t.(*UndoChanged)
+	hud.undoable = ev.Moves
+	hud.escapable = nil
+}
+
+func (hud *HUD) handleCombatSummarised(t event.Typer) {
+	ev := t.(*CombatSummarised)
+	hud.summary = ev.Summary
+}
+
+// summaryData is the Data of the summary UI.
+func (hud *HUD) summaryData() interface{} {
+	type squad struct {
+		Heading    string
+		Characters []CharacterSummary
+	}
+	squads := []squad{}
+	for _, ss := range hud.summary.Squads {
+		heading := fmt.Sprintf("Enemies: %v", ss.Result)
+		if ss.Player {
+			heading = fmt.Sprintf("Your squad: %v", ss.Result)
+		}
+		squads = append(squads, squad{
+			Heading:    heading,
+			Characters: ss.Characters,
+		})
+	}
+	return struct {
+		Banner         string
+		Squads         []squad
+		HandleContinue func(string)
+	}{
+		Banner: hud.summary.Banner,
+		Squads: squads,
+		HandleContinue: func(string) {
+			hud.summary = nil
+			hud.bus.Publish(&SummaryDismissed{})
+		},
+	}
+}
+
+// cooldownLabel describes what remains of a cooldown, as the turns followed by
+// the preparation, which is suffixed with "p". It is empty when the cooldown
+// has elapsed.
+func cooldownLabel(cd skill.Cooldown) string {
+	label := ""
+	if cd.Turns > 0 {
+		label = strconv.Itoa(cd.Turns)
+	}
+	if cd.Preparation > 0 {
+		if label != "" {
+			label += " "
+		}
+		label += strconv.Itoa(cd.Preparation) + "p"
+	}
+	return label
+}
+
 func (hud *HUD) skillsForParticipant(p *Participant) [7]UISkillInfoRow {
 	// convert a *skill.Description to a UISkillInfo
 	convert := func(sd *skill.Description) UISkillInfo {
 		spr := sd.Icon.Frames[sd.Icon.Index()]
+		availability := p.SkillAvailability(sd)
 		return UISkillInfo{
 			Id:      string(sd.ID),
 			Texture: spr.Texture,
@@ -209,6 +356,8 @@ func (hud *HUD) skillsForParticipant(p *Participant) [7]UISkillInfoRow {
 					Code: sd.ID,
 				})
 			},
+			Disabled:  !availability.Usable(),
+			Remaining: cooldownLabel(availability.Cooldown),
 		}
 	}
 
@@ -244,8 +393,13 @@ func (hud *HUD) skillsForParticipant(p *Participant) [7]UISkillInfoRow {
 			IconX:   184,
 			IconY:   24,
 			Handle: func(string) {
+				if !hud.fleeing && leavesFallenBehind(hud.mgr, hud.turnToken) {
+					hud.fleeing = true
+					return
+				}
 				hud.bus.Publish(&AttemptingEscape{Entity: hud.turnToken})
 			},
+			Disabled: !hud.canEscape() || p.ActionPoints.Cur < escapeCost,
 		}
 
 		// End turn
@@ -282,6 +436,8 @@ func (hud *HUD) skillsForParticipant(p *Participant) [7]UISkillInfoRow {
 			slot.IconX = info.IconX
 			slot.IconY = info.IconY
 			slot.Handle = info.Handle
+			slot.Disabled = info.Disabled
+			slot.Remaining = info.Remaining
 		}
 
 	} else {
@@ -294,9 +450,66 @@ func (hud *HUD) skillsForParticipant(p *Participant) [7]UISkillInfoRow {
 			},
 		}
 	}
+	for i := range result {
+		for j := range result[i].Skills {
+			result[i].Skills[j].Hover = hud.hoverSkill
+		}
+	}
 	return result
 }
 
+// hoverSkill previews the cost of the skill id in the turn order timeline, or
+// stops previewing when id is empty.
+func (hud *HUD) hoverSkill(id string) {
+	hud.hovering = skill.ID(id)
+}
+
+// previewDelay is how much the skill being previewed would delay the next turn
+// of the Participant whose turn it is.
+func (hud *HUD) previewDelay() int {
+	id := hud.hovering
+	if id == "" {
+		id = hud.previewing
+	}
+	if id == "" {
+		return 0
+	}
+	sd := hud.archive.Skill(id)
+	if sd == nil {
+		return 0
+	}
+	return sd.Costs[skill.CostsPreparation]
+}
+
+// preview describes the consequences of the action the player is about to
+// take.
+func (hud *HUD) preview() string {
+	if hud.fleeing {
+		return "Flee again to leave the fallen behind"
+	}
+	return hud.approachPreview()
+}
+
+// approachPreview describes how the Approach of the attack being confirmed
+// modifies its damage.
+func (hud *HUD) approachPreview() string {
+	if hud.confirming == nil {
+		return ""
+	}
+	if !hud.archive.Skill(hud.confirming.Skill).IsAttack() {
+		return ""
+	}
+	for _, e := range hud.mgr.Get([]string{"Participant", "Obstacle"}) {
+		obstacle := hud.mgr.Component(e, "Obstacle").(*game.Obstacle)
+		if !obstacle.Occupies(hud.confirming.Target) {
+			continue
+		}
+		approach, modifier := attackApproach(hud.mgr, hud.archive.ApproachModifiers(), hud.turnToken, e)
+		return fmt.Sprintf("%s: x%.2f", approachLabels[approach], modifier.Damage)
+	}
+	return ""
+}
+
 // Update the HUD. Synchronise the current game state to the Entities that compose it.
 func (hud *HUD) Update(elapsed time.Duration) {
 	e := hud.mgr.AnyTagged(timePassingTag)
@@ -308,28 +521,13 @@ func (hud *HUD) Update(elapsed time.Duration) {
 		return
 	}
 
-	participantEntities := hud.mgr.Get([]string{"Participant"})
-	participants := make([]*Participant, len(participantEntities))
-	for i, e := range participantEntities {
-		participants[i] = hud.mgr.Component(e, "Participant").(*Participant)
+	current := hud.turnToken
+	if hud.lastCombatState == PreparingState {
+		current = 0
 	}
-	sort.Slice(participants, func(i, j int) bool {
-		ip, jp := participants[i], participants[j]
-		ipRem := ip.PreparationThreshold.Max - ip.PreparationThreshold.Cur
-		jpRem := jp.PreparationThreshold.Max - jp.PreparationThreshold.Cur
-		if ipRem != jpRem {
-			return ipRem < jpRem
-		}
-		return ip.Disambiguator < jp.Disambiguator
-	})
-
-	turnQueue := make([]QueuedParticipant, 0)
-	for _, participant := range participants {
-		// Knocked Down and Escaped Characters cannot take turns.
-		if participant.Status != Alive {
-			continue
-		}
-
+	turnQueue := make([]QueuedParticipant, 0, timelineLength)
+	for _, turn := range PredictTurns(hud.mgr, current, hud.previewDelay(), timelineLength) {
+		participant := hud.mgr.Component(turn.Entity, "Participant").(*Participant)
 		turnQueue = append(turnQueue, QueuedParticipant{
 			Background:    participant.SmallPortraitBG.Texture,
 			BackgroundY:   participant.SmallPortraitBG.Y,
@@ -343,6 +541,7 @@ func (hud *HUD) Update(elapsed time.Duration) {
 
 			Prep:    participant.PreparationThreshold.Cur,
 			PrepMax: participant.PreparationThreshold.Max,
+			Elapsed: turn.Elapsed,
 		})
 	}
 
@@ -351,12 +550,11 @@ func (hud *HUD) Update(elapsed time.Duration) {
 	switch hud.lastCombatState {
 	case PreparingState:
 		hud.turnQueueUIComponent.Data = struct{ TurnQueue []QueuedParticipant }{
-			// NB the turn queue contains ALL participants, and is the only relevant
-			// field for the turnQueue UI.
+			// NB the turn queue is the only relevant field for the turnQueue UI.
 			TurnQueue: turnQueue,
 		}
 		hud.mgr.AddComponent(hud.uiEntity, hud.turnQueueUIComponent)
-	case AwaitingInputState, SelectingTargetState:
+	case AwaitingInputState, SelectingTargetState, ConfirmingSelectedTargetState:
 		participant := hud.mgr.Component(hud.turnToken, "Participant").(*Participant)
 		hud.fullUIComponent.Data = HUDData{
 			Background:    participant.BigPortraitBG.Texture,
@@ -372,22 +570,106 @@ func (hud *HUD) Update(elapsed time.Duration) {
 			Name:      participant.Name,
 			Health:    participant.CurrentHealth,
 			HealthMax: participant.maxHealth(),
-			Energy:    0, // FIXME: implement energy
-			EnergyMax: 0, // FIXME: implement energy
+			Energy:    participant.Energy.Cur,
+			EnergyMax: participant.Energy.Max,
 			Action:    participant.ActionPoints.Cur,
 			ActionMax: participant.ActionPoints.Max,
 			Prep:      participant.PreparationThreshold.Cur,
 			PrepMax:   participant.PreparationThreshold.Max,
 
-			// NB the turn queue contains all but the prepared participant.
-			TurnQueue: turnQueue[:len(turnQueue)-1],
+			TurnQueue: turnQueue,
 
 			Skills: hud.skillsForParticipant(participant),
+
+			Preview: hud.preview(),
+
+			Undoable: hud.lastCombatState == AwaitingInputState && hud.undoable > 0,
+			HandleUndo: func(string) {
+				hud.bus.Publish(&UndoRequested{})
+			},
 		}
 		hud.mgr.AddComponent(hud.uiEntity, hud.fullUIComponent)
+	case DeployingState:
+		hud.deployUIComponent.Data = struct {
+			HandleFight    func(string)
+			HandleRemember func(string)
+		}{
+			HandleFight: func(string) {
+				hud.bus.Publish(&DeploymentConfirmed{})
+			},
+			HandleRemember: func(string) {
+				hud.bus.Publish(&DeploymentConfirmed{SaveFormation: true})
+			},
+		}
+		hud.mgr.AddComponent(hud.uiEntity, hud.deployUIComponent)
+	case Celebration:
+		if hud.summary != nil {
+			hud.summaryUIComponent.Data = hud.summaryData()
+			hud.mgr.AddComponent(hud.uiEntity, hud.summaryUIComponent)
+		}
 	default:
 		// Do neither
 	}
+
+	hud.mgr.RemoveComponent(hud.logEntity, &ui.UI{})
+	switch hud.lastCombatState {
+	case PreparingState, AwaitingInputState, SelectingTargetState, ConfirmingSelectedTargetState, ExecutingState:
+		hud.logUIComponent.Data = hud.logData()
+		hud.mgr.AddComponent(hud.logEntity, hud.logUIComponent)
+	}
+}
+
+// logData is the Data of the log panel, which shows a page of the combat log
+// that can be scrolled back through, and the speed controls.
+func (hud *HUD) logData() interface{} {
+	entries := hud.log.Entries()
+	maxScroll := len(entries) - logLines
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if hud.logScroll > maxScroll {
+		hud.logScroll = maxScroll
+	}
+	end := len(entries) - hud.logScroll
+	start := end - logLines
+	if start < 0 {
+		start = 0
+	}
+	lines := make([]string, 0, logLines)
+	for _, entry := range entries[start:end] {
+		lines = append(lines, entry.Text)
+	}
+
+	instant := "Instant off"
+	if hud.instant {
+		instant = "Instant on"
+	}
+
+	return struct {
+		Lines         []string
+		Speed         string
+		Instant       string
+		HandleOlder   func(string)
+		HandleNewer   func(string)
+		HandleSpeed   func(string)
+		HandleInstant func(string)
+	}{
+		Lines:   lines,
+		Speed:   hud.speed.Label(),
+		Instant: instant,
+		HandleOlder: func(string) {
+			hud.logScroll = min(hud.logScroll+logLines, maxScroll)
+		},
+		HandleNewer: func(string) {
+			hud.logScroll = max(hud.logScroll-logLines, 0)
+		},
+		HandleSpeed: func(string) {
+			hud.bus.Publish(&SpeedRequested{Speed: hud.speed.next(), Instant: hud.instant})
+		},
+		HandleInstant: func(string) {
+			hud.bus.Publish(&SpeedRequested{Speed: hud.speed, Instant: !hud.instant})
+		},
+	}
 }
 
 func (hud *HUD) showTimePassingIcon() {
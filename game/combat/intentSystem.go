@@ -60,31 +60,29 @@ func (s *IntentSystem) Update() {
 
 		s.mgr.RemoveComponent(e, intent)
 
-		var start, goal geom.Key
+		var goal geom.Key
 		var stepToWaypoint func(graph.Step) Waypoint
 
-		startHex := s.field.At(pos.Center.X, pos.Center.Y)
+		footprint := footprintOf(s.mgr, e)
+		startHex := s.field.Get(footprintAnchor(s.field, footprint, pos.Center.X, pos.Center.Y))
 		goalHex := s.field.At(intent.X, intent.Y)
 		if startHex == nil || goalHex == nil || startHex.Key() == goalHex.Key() {
 			// Don't navigate.
 			s.Publish(&ParticipantMovementConcluded{Entity: e})
 			continue
 		}
-		start = startHex.Key()
 		goal = goalHex.Key()
 		stepToWaypoint = func(step graph.Step) Waypoint {
 			k := step.V.(geom.Key)
-			x, y := s.field.Ktow(k)
+			x, y := footprintCenter(s.field, footprint, k)
 			return Waypoint{
-				X: x,
-				Y: y,
+				X:    x,
+				Y:    y,
+				Cost: int(step.Cost),
 			}
 		}
 
-		costs := CostsFuncFactory(s.field, s.mgr, e)
-		edges := EdgeFuncFactory(s.field)
-		guess := HeuristicFactory(s.field)
-		steps := graph.NewSearcher(costs, edges, guess).Search(start, goal)
+		steps := MoveRange(s.field, s.mgr, e).Path(goal)
 		if steps == nil {
 			fmt.Printf("Search: no path to %v\n", goal)
 			s.Publish(&ParticipantMovementConcluded{Entity: e})
@@ -123,8 +121,15 @@ func ExistsFuncFactory(f *geom.Field) ExistsFunc {
 }
 
 // CostsFuncFactory constructs a CostsFunc that returns the costs of moving from
-// one location to another for an Entity from a context.
+// one location to another for an Entity from a context. The locations are the
+// anchors of the Entity's Footprint, and every hex of the Footprint must be
+// passable.
 func CostsFuncFactory(f *geom.Field, mgr *ecs.World, participantEntity ecs.Entity) graph.CostFunc {
+	footprint := footprintOf(mgr, participantEntity)
+	var traversal game.Traversal
+	if participant, ok := mgr.Component(participantEntity, "Participant").(*Participant); ok {
+		traversal = participant.Traversal
+	}
 	var obstacles []ContextualObstacle
 	for _, e := range mgr.Get([]string{"Obstacle"}) {
 		// A Participant is not an obstacle to itself.
@@ -142,39 +147,68 @@ func CostsFuncFactory(f *geom.Field, mgr *ecs.World, participantEntity ecs.Entit
 
 		// maybe anything other than nonobstacle is a total blocker?
 		cost := math.Inf(0)
-		// TODO: extra rules for more than just mud here.
 		if obstacle.ObstacleType == game.MudObstacle {
 			cost = 2.0
 		}
-		obstacles = append(obstacles, ContextualObstacle{
-			M:    obstacle.M,
-			N:    obstacle.N,
-			Cost: cost,
-		})
+		if c, ok := traversal.Obstacles[obstacle.ObstacleType]; ok {
+			cost = c
+		}
+		for _, k := range obstacle.Keys() {
+			obstacles = append(obstacles, ContextualObstacle{
+				M:    k.M,
+				N:    k.N,
+				Cost: cost,
+			})
+		}
 	}
+	terrain := terrainByKey(mgr)
 
 	return func(vFrom, vTo graph.Vertex) float64 {
-		// from := vFrom.(geom.Key)
+		from := vFrom.(geom.Key)
 		to := vTo.(geom.Key)
 
-		hex := f.Get(to)
-
-		if hex == nil {
-			return math.Inf(0)
-		}
-		cost := 10.0
-		for _, o := range obstacles {
-			if to == (geom.Key{M: o.M, N: o.N}) {
-				if math.IsInf(o.Cost, 0) {
-					return math.Inf(0)
+		// The costs of the terrain and obstacles of a hex multiply together,
+		// and the slowest hex of the Footprint determines the cost.
+		multiplier := 0.0
+		for _, k := range footprint.Keys(to) {
+			if f.Get(k) == nil {
+				return math.Inf(0)
+			}
+			hexMultiplier := 1.0
+			if t, ok := terrain[k]; ok {
+				hexMultiplier *= t.Multiplier(traversal)
+			}
+			for _, o := range obstacles {
+				if k == (geom.Key{M: o.M, N: o.N}) {
+					if math.IsInf(o.Cost, 0) {
+						return math.Inf(0)
+					}
+					hexMultiplier *= o.Cost
 				}
-				cost = cost * o.Cost
 			}
+			multiplier = math.Max(multiplier, hexMultiplier)
+		}
+		if math.IsInf(multiplier, 0) {
+			return math.Inf(0)
 		}
-		return cost
+
+		// Climbing is slower than walking on the level or downhill.
+		if climb := elevationOf(terrain, to) - elevationOf(terrain, from); climb > 0 {
+			multiplier += climbCost * float64(climb)
+		}
+		return 10.0 * multiplier
 	}
 }
 
+// MoveRange finds the cost for a Participant to move to every hex it can reach
+// on the field. The IntentSystem moves Participants along the paths it finds,
+// so anything that previews movement should use it too.
+func MoveRange(f *geom.Field, mgr *ecs.World, e ecs.Entity) *graph.Reachable {
+	obstacle := mgr.Component(e, "Obstacle").(*game.Obstacle)
+	start := geom.Key{M: obstacle.M, N: obstacle.N}
+	return graph.Flood(CostsFuncFactory(f, mgr, e), EdgeFuncFactory(f), start, math.Inf(1))
+}
+
 // EdgeFuncFactory generates a function that returns the connected Keys of a Key
 // given the context of a Field.
 func EdgeFuncFactory(f *geom.Field) graph.EdgeFunc {
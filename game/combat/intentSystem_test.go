@@ -0,0 +1,86 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+)
+
+func TestMoveRangeAgreesWithIntentSystem(t *testing.T) {
+	field := newTestField(t, 8, 8)
+	mgr := ecs.NewWorld()
+
+	e := mgr.NewEntity()
+	start := geom.Key{M: 1, N: 3}
+	x, y := field.Ktow(start)
+	mgr.AddComponent(e, &Participant{ActionPoints: CurMax{Cur: 40, Max: 60}})
+	mgr.AddComponent(e, &game.Position{Center: game.Center{X: x, Y: y}})
+	mgr.AddComponent(e, &game.Obstacle{M: start.M, N: start.N, ObstacleType: game.CharacterObstacle})
+
+	// A wall of trees with a gap at the north forces a detour.
+	for n := 1; n < 8; n++ {
+		tree := mgr.NewEntity()
+		mgr.AddComponent(tree, &game.Obstacle{M: 3, N: n, ObstacleType: game.TreeObstacle})
+	}
+	mud := mgr.NewEntity()
+	mgr.AddComponent(mud, &game.Obstacle{M: 1, N: 4, ObstacleType: game.MudObstacle})
+
+	reachable := MoveRange(field, mgr, e)
+	if reachable.Costs[start] != 0 {
+		t.Errorf("want start to cost nothing, got %f", reachable.Costs[start])
+	}
+	if got := reachable.Costs[geom.Key{M: 1, N: 4}]; got != 20 {
+		t.Errorf("want mud to cost 20, got %f", got)
+	}
+	if reachable.Contains(geom.Key{M: 3, N: 3}) {
+		t.Errorf("want trees unreachable")
+	}
+
+	// Movement beyond the wall must go through the gap at 3,0.
+	goal := geom.Key{M: 4, N: 3}
+	path := reachable.Path(goal)
+	if path == nil {
+		t.Fatalf("want a path around the trees")
+	}
+	through := false
+	for _, step := range path {
+		if step.V == (geom.Key{M: 3, N: 0}) {
+			through = true
+		}
+	}
+	if !through {
+		t.Errorf("want path through the gap, got %v", path)
+	}
+
+	// The IntentSystem follows the same path, until it runs out of AP.
+	gx, gy := field.Ktow(goal)
+	mgr.AddComponent(e, &MoveIntent{X: gx, Y: gy})
+	NewIntentSystem(mgr, &event.Bus{}, field).Update()
+
+	mover, ok := mgr.Component(e, "Mover").(*Mover)
+	if !ok {
+		t.Fatalf("want a Mover")
+	}
+	affordable := 0
+	for _, step := range path {
+		if step.Cost <= 40 {
+			affordable++
+		}
+	}
+	if len(mover.Moves) != affordable {
+		t.Fatalf("want %d moves, got %d", affordable, len(mover.Moves))
+	}
+	for i, wp := range mover.Moves {
+		wx, wy := field.Ktow(path[i].V.(geom.Key))
+		if wp.X != wx || wp.Y != wy {
+			t.Errorf("move %d: want %v, got %f,%f", i, path[i].V, wp.X, wp.Y)
+		}
+	}
+	participant := mgr.Component(e, "Participant").(*Participant)
+	if want := 40 - int(path[affordable-1].Cost); participant.ActionPoints.Cur != want {
+		t.Errorf("want %d AP remaining, got %d", want, participant.ActionPoints.Cur)
+	}
+}
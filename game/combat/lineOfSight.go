@@ -0,0 +1,56 @@
+package combat
+
+import (
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+	"github.com/griffithsh/squads/targeting"
+)
+
+// obstructs determines whether an Obstacle stands in the way of a skill with
+// the given trajectory. Low obstacles like crevasses and mud never block.
+func obstructs(mgr *ecs.World, e ecs.Entity, o *game.Obstacle, trajectory targeting.Trajectory) bool {
+	switch o.ObstacleType {
+	case game.TreeObstacle:
+		return trajectory != targeting.Arcing
+	case game.CharacterObstacle:
+		if trajectory != targeting.Direct {
+			return false
+		}
+		// Characters that have been knocked down are not in the way.
+		if participant, ok := mgr.Component(e, "Participant").(*Participant); ok && participant.Status != Alive {
+			return false
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// lineOfSight determines whether a skill with the given trajectory can travel
+// from origin to target, and if not, which hexes are in the way.
+func lineOfSight(mgr *ecs.World, origin, target geom.Key, trajectory targeting.Trajectory) (bool, []geom.Key) {
+	if trajectory == targeting.Arcing {
+		return true, nil
+	}
+
+	blocked := map[geom.Key]struct{}{}
+	for _, e := range mgr.Get([]string{"Obstacle"}) {
+		o := mgr.Component(e, "Obstacle").(*game.Obstacle)
+		// Neither the user nor the target are in their own way, even when
+		// they occupy more than one hex.
+		if o.Occupies(origin) || o.Occupies(target) {
+			continue
+		}
+		if obstructs(mgr, e, o, trajectory) {
+			for _, k := range o.Keys() {
+				blocked[k] = struct{}{}
+			}
+		}
+	}
+
+	return geom.LineOfSight(origin, target, func(k geom.Key) bool {
+		_, ok := blocked[k]
+		return ok
+	})
+}
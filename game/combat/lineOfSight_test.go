@@ -0,0 +1,50 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+	"github.com/griffithsh/squads/targeting"
+)
+
+func TestLineOfSight(t *testing.T) {
+	origin, target := geom.Key{M: 0, N: 0}, geom.Key{M: 0, N: 4}
+	between := geom.Key{M: 0, N: 2}
+
+	tests := []struct {
+		name       string
+		obstacle   game.ObstacleType
+		status     EngagementStatus
+		trajectory targeting.Trajectory
+		want       bool
+	}{
+		{"arrow into tree", game.TreeObstacle, Alive, targeting.Direct, false},
+		{"arrow into character", game.CharacterObstacle, Alive, targeting.Direct, false},
+		{"arrow over fallen character", game.CharacterObstacle, KnockedDown, targeting.Direct, true},
+		{"arrow over crevasse", game.CrevasseObstacle, Alive, targeting.Direct, true},
+		{"lob over tree", game.TreeObstacle, Alive, targeting.Arcing, true},
+		{"lob over character", game.CharacterObstacle, Alive, targeting.Arcing, true},
+		{"pierce into tree", game.TreeObstacle, Alive, targeting.Piercing, false},
+		{"pierce through character", game.CharacterObstacle, Alive, targeting.Piercing, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mgr := ecs.NewWorld()
+			e := mgr.NewEntity()
+			mgr.AddComponent(e, &game.Obstacle{M: between.M, N: between.N, ObstacleType: tc.obstacle})
+			if tc.obstacle == game.CharacterObstacle {
+				mgr.AddComponent(e, &Participant{Status: tc.status})
+			}
+
+			got, obstructions := lineOfSight(mgr, origin, target, tc.trajectory)
+			if got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+			if !got && (len(obstructions) != 1 || obstructions[0] != between) {
+				t.Errorf("want obstruction at %v, got %v", between, obstructions)
+			}
+		})
+	}
+}
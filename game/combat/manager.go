@@ -7,12 +7,15 @@ import (
 	"sort"
 	"time"
 
+	"github.com/griffithsh/squads/approach"
 	"github.com/griffithsh/squads/baddy"
 	"github.com/griffithsh/squads/data"
 	"github.com/griffithsh/squads/ecs"
 	"github.com/griffithsh/squads/event"
 	"github.com/griffithsh/squads/game"
 	"github.com/griffithsh/squads/game/item"
+	"github.com/griffithsh/squads/game/overworld/procedural"
+	"github.com/griffithsh/squads/game/stats"
 	"github.com/griffithsh/squads/geom"
 	"github.com/griffithsh/squads/skill"
 	"github.com/griffithsh/squads/targeting"
@@ -26,6 +29,7 @@ type SkillArchive interface {
 	SkillsByWeaponClass(item.Class) []*skill.Description
 	Appearance(profession string, sex game.CharacterSex, hair string, skin string) *game.Appearance
 	Profession(profession string) *game.ProfessionDetails
+	ApproachModifiers() approach.Table
 }
 
 // Manager is a game-mode. It processes turns-based Combat until one or the other
@@ -39,9 +43,14 @@ type Manager struct {
 	nav     *Navigator
 	camera  *game.Camera
 	hud     *HUD
+	log     *CombatLog
 	cursors *CursorManager
 	se      *skillExecutor
 	ds      *damageSystem
+	hs      *hazardSystem
+	rs      *reactionSystem
+	ss      *summonSystem
+	us      *undoSystem
 
 	turnToken            ecs.Entity // Whose turn is it? References an existing Entity.
 	selectingInteractive ecs.Entity // catches clicks on the field.
@@ -53,6 +62,11 @@ type Manager struct {
 
 	incrementAccumulator float64
 
+	// speed is how fast combat plays out, and instant resolves the turns of
+	// computer controlled Participants without waiting for animations.
+	speed   Speed
+	instant bool
+
 	x, y             int       // where the mouse last was in screen coordinates
 	screenW, screenH int       // most recent dimensions of the window
 	selectedHex      *geom.Key // most recent hex selected
@@ -62,6 +76,9 @@ type Manager struct {
 
 	celebrations time.Duration
 
+	// summary of the combat, once it is over.
+	summary *Summary
+
 	squads []ecs.Entity
 
 	// vanishers is the list of entities that will vanish when a participant
@@ -72,6 +89,7 @@ type Manager struct {
 // NewManager creates a new combat Manager.
 func NewManager(mgr *ecs.World, camera *game.Camera, bus *event.Bus, archive *data.Archive) *Manager {
 	f := geom.NewField(hexagonBodyWidth, hexagonWingWidth, hexagonHeight)
+	combatLog := NewCombatLog(mgr, bus, archive)
 
 	cm := Manager{
 		mgr:                  mgr,
@@ -81,10 +99,15 @@ func NewManager(mgr *ecs.World, camera *game.Camera, bus *event.Bus, archive *da
 		nav:                  NewNavigator(bus),
 		camera:               camera,
 		state:                Uninitialised,
-		hud:                  NewHUD(mgr, bus, camera.GetW(), camera.GetH(), archive),
+		log:                  combatLog,
+		hud:                  NewHUD(mgr, bus, camera.GetW(), camera.GetH(), archive, combatLog),
 		cursors:              NewCursorManager(mgr, bus, archive, f),
 		se:                   newSkillExecutor(mgr, bus, f, archive),
 		ds:                   newDamageSystem(mgr, bus),
+		hs:                   newHazardSystem(mgr, bus, f),
+		rs:                   newReactionSystem(mgr, bus, f, archive),
+		ss:                   newSummonSystem(mgr, bus),
+		us:                   newUndoSystem(mgr, bus),
 		selectingInteractive: mgr.NewEntity(),
 		intents:              NewIntentSystem(mgr, bus, f),
 		performances:         NewPerformanceSystem(mgr, bus, archive),
@@ -102,6 +125,10 @@ func NewManager(mgr *ecs.World, camera *game.Camera, bus *event.Bus, archive *da
 	cm.bus.Subscribe(CharacterEnteredCombat{}.Type(), cm.handleCharacterEnteredCombat)
 	cm.bus.Subscribe(ParticipantDefiled{}.Type(), cm.handleParticipantDefiled)
 	cm.bus.Subscribe(ParticipantMoving{}.Type(), cm.handleParticipantMoving)
+	cm.bus.Subscribe(DeploymentConfirmed{}.Type(), cm.handleDeploymentConfirmed)
+	cm.bus.Subscribe(SummaryDismissed{}.Type(), cm.handleSummaryDismissed)
+	cm.bus.Subscribe(SpeedRequested{}.Type(), cm.handleSpeedRequested)
+	cm.bus.Subscribe(UndoRequested{}.Type(), cm.handleUndoRequested)
 
 	return &cm
 }
@@ -112,31 +139,15 @@ func (cm *Manager) handleTargetSelected(x, y float64) {
 	h := cm.field.At(x, y)
 
 	s := cm.archive.Skill(ctx.Skill)
-	switch s.Targeting.Selectable.Type {
-	case targeting.SelectAnywhere:
-		// No filtering is applicable.
-	case targeting.SelectWithin:
-		if h == nil {
-			return
-		}
-		obstacle := cm.mgr.Component(cm.turnToken, "Obstacle").(*game.Obstacle)
-		origin := geom.Key{M: obstacle.M, N: obstacle.N}
-		valid := origin.ExpandBy(s.Targeting.Selectable.MinRange, s.Targeting.Selectable.MaxRange)
-		inRange := false
-		for _, key := range valid {
-			if key == h.Key() {
-				inRange = true
-				break
-			}
-		}
-		if !inRange {
+	if h == nil {
+		// Only skills that can be selected anywhere can select nothing.
+		if s.Targeting.Selectable.Type != targeting.SelectAnywhere {
 			return
 		}
+	} else if ctx.Skill != skill.BasicMovement && !CanTarget(cm.mgr, cm.field, cm.turnToken, s, h.Key()) {
+		return
 	}
 
-	// TODO: extra validation needs to happen here - you can only raise skeleton
-	// if there is a downed character here, right?
-
 	var selected *geom.Key
 	if h != nil {
 		// Go to confirming state if a Hex was selected, and save the Key of the
@@ -178,35 +189,22 @@ func (cm *Manager) handleTargetConfirmed(x, y float64) {
 
 	// Special handling for movement.
 	if ctx.Skill == skill.BasicMovement {
+		cm.us.Record(cm.turnToken)
 		cm.mgr.AddComponent(cm.turnToken, &MoveIntent{X: x, Y: y})
 		cm.setState(ExecutingState)
 		return
 	}
 
 	s := cm.archive.Skill(ctx.Skill)
-	switch s.Targeting.Selectable.Type {
-	case targeting.SelectAnywhere:
-		// Because we can target anywhere, there are no reasons to return out of
-		// this function for this case.
-	case targeting.SelectWithin:
-		valid := origin.Key().ExpandBy(s.Targeting.Selectable.MinRange, s.Targeting.Selectable.MaxRange)
-		inRange := false
-
-		for _, key := range valid {
-			if key == selected.Key() {
-				inRange = true
-				break
-			}
-		}
-		if !inRange {
-			return
-		}
+	if !CanTarget(cm.mgr, cm.field, cm.turnToken, s, selected.Key()) {
+		return
 	}
 
 	cm.setState(ExecutingState)
 
-	if s.IsAttack() {
-		cm.mgr.AddComponent(cm.turnToken, &game.Facer{Face: geom.S})
+	if s.IsAttack() && origin.Key() != selected.Key() {
+		// Turn to face the target of the attack.
+		cm.mgr.AddComponent(cm.turnToken, &game.Facer{Face: geom.FindDirection(origin.Key(), selected.Key())})
 	}
 	cm.bus.Publish(&UsingSkill{
 		User:     cm.turnToken,
@@ -230,7 +228,7 @@ func (cm *Manager) setState(stateContext StateContext) {
 	// When entering Selecting Target State, we need to add an Interactive to
 	// cover all areas of the field, so that we can convert those clicks to
 	// MoveIntents.
-	if ev.Old.Value() == SelectingTargetState || ev.Old.Value() == ConfirmingSelectedTargetState {
+	if ev.Old.Value() == SelectingTargetState || ev.Old.Value() == ConfirmingSelectedTargetState || ev.Old.Value() == DeployingState {
 		cm.mgr.RemoveComponent(cm.selectingInteractive, &ui.Interactive{})
 	}
 	switch state.Value() {
@@ -256,6 +254,12 @@ func (cm *Manager) setState(stateContext StateContext) {
 			W: math.MaxFloat64, H: math.MaxFloat64,
 			Trigger: cm.handleTargetConfirmed,
 		})
+	case DeployingState:
+		cm.mgr.AddComponent(cm.selectingInteractive, &game.Position{})
+		cm.mgr.AddComponent(cm.selectingInteractive, &ui.Interactive{
+			W: math.MaxFloat64, H: math.MaxFloat64,
+			Trigger: cm.handleDeployClick,
+		})
 	}
 
 	cm.bus.Publish(&ev)
@@ -302,11 +306,18 @@ func semiSort(m, n int, f *geom.Field) []*geom.Hex {
 	return result
 }
 
-// isBlocked determines if a Character can be placed at m,n.
-func isBlocked(field *geom.Field, k geom.Key, mgr *ecs.World) bool {
+// isBlocked determines if a Character with the given Footprint can be placed
+// with its anchor at k. Obstacles of the ignored Entities do not block.
+func isBlocked(field *geom.Field, k geom.Key, footprint game.Footprint, mgr *ecs.World, ignore ...ecs.Entity) bool {
 	// blockages is a set of Keys that are taken by other things
 	blockages := map[geom.Key]struct{}{}
+nextObstacle:
 	for _, e := range mgr.Get([]string{"Obstacle"}) {
+		for _, ignored := range ignore {
+			if e == ignored {
+				continue nextObstacle
+			}
+		}
 		o := mgr.Component(e, "Obstacle").(*game.Obstacle)
 
 		h := field.Get(geom.Key{M: o.M, N: o.N})
@@ -317,16 +328,18 @@ func isBlocked(field *geom.Field, k geom.Key, mgr *ecs.World) bool {
 		// FIXME: We're making the assumption again here that all obstacles
 		// are total obstacles. Even conceptually things like shallow water
 		// or bushes that should only impede movement slightly.
-		blockages[h.Key()] = struct{}{}
-	}
-
-	hex := field.Get(k)
-	if hex == nil {
-		return true
+		for _, key := range o.Keys() {
+			blockages[key] = struct{}{}
+		}
 	}
 
-	if _, blocked := blockages[k]; blocked {
-		return true
+	for _, key := range footprint.Keys(k) {
+		if field.Get(key) == nil {
+			return true
+		}
+		if _, blocked := blockages[key]; blocked {
+			return true
+		}
 	}
 
 	return false
@@ -341,6 +354,9 @@ func isBlocked(field *geom.Field, k geom.Key, mgr *ecs.World) bool {
 type startProvider struct {
 	starts []geom.Key
 	used   map[int64][]*geom.Hex
+
+	// assigned stores the start of each team.
+	assigned map[int64]geom.Key
 }
 
 func newStartProvider(starts []geom.Key) *startProvider {
@@ -348,8 +364,9 @@ func newStartProvider(starts []geom.Key) *startProvider {
 		starts[i], starts[j] = starts[j], starts[i]
 	})
 	return &startProvider{
-		starts: starts,
-		used:   map[int64][]*geom.Hex{},
+		starts:   starts,
+		used:     map[int64][]*geom.Hex{},
+		assigned: map[int64]geom.Key{},
 	}
 }
 
@@ -357,13 +374,14 @@ func (sp *startProvider) getNearby(team *game.Team, f *geom.Field) []*geom.Hex {
 	if _, ok := sp.used[team.ID]; !ok {
 		s := sp.starts[len(sp.used)]
 		sp.used[team.ID] = semiSort(s.M, s.N, f)
+		sp.assigned[team.ID] = s
 	}
 	return sp.used[team.ID]
 }
 
-func (cm *Manager) getStart(nearbys []*geom.Hex) *geom.Hex {
+func (cm *Manager) getStart(nearbys []*geom.Hex, footprint game.Footprint) *geom.Hex {
 	for _, h := range nearbys {
-		if isBlocked(cm.field, h.Key(), cm.mgr) {
+		if isBlocked(cm.field, h.Key(), footprint, cm.mgr) {
 			continue
 		}
 
@@ -372,8 +390,9 @@ func (cm *Manager) getStart(nearbys []*geom.Hex) *geom.Hex {
 	return nil
 }
 
-// createParticipation adds a new Entity to participate in combat based on a Character.
-func (cm *Manager) createParticipation(charEntity ecs.Entity, team *game.Team, atHex *geom.Hex) {
+// createParticipation adds a new Entity to participate in combat based on a
+// Character, and returns it.
+func (cm *Manager) createParticipation(charEntity ecs.Entity, team *game.Team, atHex *geom.Hex) ecs.Entity {
 	e := cm.mgr.NewEntity()
 	cm.mgr.Tag(e, "combat")
 
@@ -381,6 +400,7 @@ func (cm *Manager) createParticipation(charEntity ecs.Entity, team *game.Team, a
 	equipment, _ := cm.mgr.Component(charEntity, "Equipment").(*item.Equipment)
 	char := cm.mgr.Component(charEntity, "Character").(*game.Character)
 	prof := cm.archive.Profession(char.Profession)
+	attrs := stats.Derive(char, prof, equipment)
 
 	app := cm.archive.Appearance(char.Profession, char.Sex, char.Hair, char.Skin)
 
@@ -404,18 +424,22 @@ func (cm *Manager) createParticipation(charEntity ecs.Entity, team *game.Team, a
 		BigIcon:            app.BigIcon(),
 		Profession:         char.Profession,
 		Sex:                char.Sex,
+		Traversal:          prof.Traversal,
 		PreparationThreshold: CurMax{
-			Max: char.InherantPreparation + prof.Preparation + equipment.WeaponPreparation(),
+			Max: attrs.Preparation,
 		},
 		ActionPoints: CurMax{
-			Max: char.InherantActionPoints + prof.ActionPoints + equipment.WeaponActionPoints(),
+			Max: attrs.ActionPoints,
+		},
+		Energy: CurMax{
+			Max: attrs.MaxEnergy,
 		},
 		BaseHealth:    char.BaseHealth,
 		CurrentHealth: char.CurrentHealth,
-		// Strength:     int(char.StrengthPerLevel * float64(char.Level)),
-		// Agility:    0,
-		// Intelligence: 0,
-		// Vitality:     0,
+		Strength:      attrs.Strength,
+		Agility:       attrs.Agility,
+		Intelligence:  attrs.Intelligence,
+		Vitality:      attrs.Vitality,
 		Disambiguator: char.Disambiguator,
 		Masteries:     char.Masteries,
 
@@ -434,6 +458,7 @@ func (cm *Manager) createParticipation(charEntity ecs.Entity, team *game.Team, a
 
 	participant.Character = charEntity
 	participant.ActionPoints.Cur = participant.ActionPoints.Max
+	participant.Energy.Cur = participant.Energy.Max
 	participant.Status = Alive
 	cm.mgr.AddComponent(e, participant)
 
@@ -441,8 +466,7 @@ func (cm *Manager) createParticipation(charEntity ecs.Entity, team *game.Team, a
 	cm.mgr.AddComponent(e, team)
 
 	// Add Position.
-	cm.field.Ktow(atHex.Key())
-	x, y := atHex.Center()
+	x, y := footprintCenter(cm.field, prof.Footprint, atHex.Key())
 	cm.mgr.AddComponent(e, &game.Position{
 		Center: game.Center{
 			X: x,
@@ -456,30 +480,39 @@ func (cm *Manager) createParticipation(charEntity ecs.Entity, team *game.Team, a
 		M:            atHex.Key().M,
 		N:            atHex.Key().N,
 		ObstacleType: game.CharacterObstacle,
+		Footprint:    prof.Footprint,
 	}
 	cm.mgr.AddComponent(e, &o)
 
 	// Add Facer Component.
 	cm.mgr.AddComponent(e, &game.Facer{Face: geom.S})
 
-	leash := game.Leash{
-		Owner:       e,
-		LayerOffset: -1,
-	}
-	e = cm.mgr.NewEntity()
-	cm.mgr.Tag(e, "combat")
+	// Add a pedestal under every hex of the Footprint.
+	for _, k := range o.Keys() {
+		kx, ky := cm.field.Ktow(k)
+		pedestal := cm.mgr.NewEntity()
+		cm.mgr.Tag(pedestal, "combat")
 
-	spr := cm.archive.GetPedestal(team.PedestalAppearance)
+		spr := cm.archive.GetPedestal(team.PedestalAppearance)
 
-	cm.mgr.AddComponent(e, spr)
-	cm.mgr.AddComponent(e, &leash)
+		cm.mgr.AddComponent(pedestal, spr)
+		cm.mgr.AddComponent(pedestal, &game.Leash{
+			Owner:       e,
+			LayerOffset: -1,
+			X:           kx - x,
+			Y:           ky - y,
+		})
+	}
+	return e
 }
 
 // Begin should be called at the start of an engagement to set up components
-// necessary for the combat.
-// TODO: Begin should be provided with information about the squads and the
-// terrain to fight on.
-func (cm *Manager) Begin(participatingSquads []ecs.Entity) {
+// necessary for the combat. The combat map is generated for the overworld
+// terrain from seed.
+func (cm *Manager) Begin(participatingSquads []ecs.Entity, terrain procedural.Code, seed int64) {
+	// deployment is set when the local player has a squad to arrange.
+	var deployment *deployingState
+
 	cm.setState(FadingIn)
 	e := cm.mgr.NewEntity()
 	cm.mgr.Tag(e, "combat")
@@ -487,12 +520,15 @@ func (cm *Manager) Begin(participatingSquads []ecs.Entity) {
 		W: cm.screenW, H: cm.screenH,
 		Obscuring: false, // ergo revealing
 		OnComplete: func() {
+			if deployment != nil {
+				cm.setState(deployment)
+				cm.publishDeployment(deployment)
+				return
+			}
 			cm.setState(PreparingState)
 		},
 		OnInitialised: func() {
-			// TODO: we should provide the terrain we're entering combat to this
-			// method so that a map with an appropriate tileset can be selected.
-			combatMap := cm.archive.GetCombatMap()
+			combatMap := cm.archive.GetCombatMap(seed, terrain, len(participatingSquads))
 
 			keys := make([]geom.Key, len(combatMap.Hexes))
 			for i, hex := range combatMap.Hexes {
@@ -519,6 +555,19 @@ func (cm *Manager) Begin(participatingSquads []ecs.Entity) {
 						ObstacleType: hex.Obstacle,
 					})
 				}
+				// add terrain
+				e := cm.mgr.NewEntity()
+				cm.mgr.Tag(e, "combat")
+				cm.mgr.AddComponent(e, &game.Terrain{
+					M:           h.Key().M,
+					N:           h.Key().N,
+					TerrainType: hex.Terrain,
+					Cost:        hex.Cost,
+					Elevation:   hex.Elevation,
+					Cover:       hex.Cover,
+					Hazard:      hex.Hazard,
+					Escape:      hex.Escape,
+				})
 				for _, vis := range hex.Visuals {
 					if len(vis.Frames) == 0 {
 						continue
@@ -573,28 +622,43 @@ func (cm *Manager) Begin(participatingSquads []ecs.Entity) {
 				}
 			}
 
-			// TODO:
-			// There is some entity which stores info about a "level", and produces
-			// artifacts that can be used by the combat Manager. It should produce the
-			// shape of the level, and the terrain of each hex (grass, water, blocked by
-			// tree etc). It should also produce starting positions for teams...
-
 			sp := newStartProvider(combatMap.Starts)
 
-			entities := []ecs.Entity{}
-			for _, e := range participatingSquads {
-				cm.squads = append(cm.squads, e)
-				squad := cm.mgr.Component(e, "Squad").(*game.Squad)
-				entities = append(entities, squad.Members...)
-			}
-
 			// Create a Participating Entity for every Character we have.
-			for _, e := range entities {
-				team := cm.mgr.Component(e, "Team").(*game.Team)
-				near := sp.getNearby(team, cm.field)
-				h := cm.getStart(near)
+			for _, se := range participatingSquads {
+				cm.squads = append(cm.squads, se)
+				squad := cm.mgr.Component(se, "Squad").(*game.Squad)
+
+				// Members with a place in the Squad's Formation take it before
+				// the others fill in around them.
+				members := append([]ecs.Entity{}, squad.Members...)
+				sort.SliceStable(members, func(i, j int) bool {
+					_, iok := squad.Formation[members[i]]
+					_, jok := squad.Formation[members[j]]
+					return iok && !jok
+				})
+				for _, e := range members {
+					team := cm.mgr.Component(e, "Team").(*game.Team)
+					char := cm.mgr.Component(e, "Character").(*game.Character)
+					footprint := cm.archive.Profession(char.Profession).Footprint
+					near := sp.getNearby(team, cm.field)
+					h, ok := formationStart(cm.field, cm.mgr, team, squad, e, sp.assigned[team.ID], footprint)
+					if !ok {
+						h = cm.getStart(near, footprint)
+					}
+
+					cm.createParticipation(e, team, h)
 
-				cm.createParticipation(e, team, h)
+					if team.Control == game.LocalControl && deployment == nil {
+						deployment = &deployingState{
+							Team:  team,
+							Start: sp.assigned[team.ID],
+						}
+					}
+				}
+			}
+			if deployment != nil {
+				deployment.Zone = deploymentZone(cm.field, cm.mgr, deployment.Team, deployment.Start)
 			}
 
 			// This is a bit of a hacky way call the code to hide or show trees
@@ -615,6 +679,7 @@ func (cm *Manager) End() {
 	cm.turnToken = 0
 	cm.setState(Uninitialised)
 	cm.squads = cm.squads[:0]
+	cm.summary = nil
 
 	// Destroy Entities that were added for combat.
 	for _, e := range cm.mgr.Tagged("combat") {
@@ -635,6 +700,12 @@ func (cm *Manager) End() {
 	cm.hud.Disable()
 }
 
+// Log of the current combat, or of the most recent combat when none is in
+// progress.
+func (cm *Manager) Log() *CombatLog {
+	return cm.log
+}
+
 // Pause the combat Manager, ignoring input and not rendering the state of the
 // combat. Pause should be called when an in-combat modal menu is entered, and a
 // return to the current combat is imminent.
@@ -676,14 +747,15 @@ func (cm *Manager) Run(elapsed time.Duration) {
 			if participant.Status != Alive {
 				continue
 			}
+			victoriousEntities = append(victoriousEntities, e)
+			if _, summoned := cm.mgr.Component(e, "Summoned").(*Summoned); summoned {
+				// Summons cannot win a combat on their own.
+				continue
+			}
 			team := cm.mgr.Component(e, "Team").(*game.Team)
 			remainingTeams[team.ID] = struct{}{}
-			victoriousEntities = append(victoriousEntities, e)
 		}
 		if len(remainingTeams) < 2 {
-			// TODO: set Victory, Escape, Defeat banner
-			// ...
-
 			for _, e := range victoriousEntities {
 				cm.bus.Publish(&CharacterCelebrating{Entity: e})
 			}
@@ -693,11 +765,16 @@ func (cm *Manager) Run(elapsed time.Duration) {
 		}
 	}
 
+	// Everything that plays out in combat time, rather than real time, uses
+	// the scaled elapsed time.
+	scaled := cm.scaled(elapsed)
+	cm.synchroniseAnimationSpeed()
+
 	switch cm.state {
 	case PreparingState:
 		// Use the elapsed time as a base for the preparation increment.
 		const prepPerSec float64 = 500
-		cm.incrementAccumulator += elapsed.Seconds() * prepPerSec
+		cm.incrementAccumulator += scaled.Seconds() * prepPerSec
 		increment := int(cm.incrementAccumulator)
 		cm.incrementAccumulator -= float64(increment)
 
@@ -719,6 +796,7 @@ func (cm *Manager) Run(elapsed time.Duration) {
 		// the damage system, so that Damage over time from injuries etc can be
 		// calculated.
 		cm.ds.ProcessDamageOverTime(increment)
+		cm.ss.Elapse(increment)
 
 		// prepared captures all Participants who are fully prepared to take their
 		// turn now.
@@ -739,6 +817,16 @@ func (cm *Manager) Run(elapsed time.Duration) {
 				Amount: increment,
 			})
 
+			participant.prepareCooldowns(increment)
+
+			if gained := participant.regenerateEnergy(increment); gained > 0 {
+				cm.bus.Publish(&StatModified{
+					Entity: e,
+					Stat:   game.EnergyStat,
+					Amount: gained,
+				})
+			}
+
 			if participant.PreparationThreshold.Cur >= participant.PreparationThreshold.Max {
 				prepared = append(prepared, e)
 			}
@@ -763,6 +851,7 @@ func (cm *Manager) Run(elapsed time.Duration) {
 			}
 			participant.PreparationThreshold.Cur = 0
 			cm.bus.Publish(ev)
+			participant.beginTurnCooldowns()
 
 			cm.turnToken = e
 			cm.bus.Publish(&ParticipantTurnChanged{Entity: cm.turnToken})
@@ -770,64 +859,45 @@ func (cm *Manager) Run(elapsed time.Duration) {
 		}
 
 	case ExecutingState:
-		cm.nav.Update(cm.mgr, elapsed)
-		cm.se.Update(elapsed)
+		cm.nav.Update(cm.mgr, scaled)
+		cm.se.Update(scaled)
+
+		// Instant mode resolves the whole action of a computer controlled
+		// Participant now, rather than animating it over the coming frames.
+		for i := 0; i < maxInstantSteps && cm.state == ExecutingState && cm.instantTurn(); i++ {
+			cm.nav.Update(cm.mgr, instantStep)
+			cm.se.Update(instantStep)
+			cm.performances.Update(instantStep)
+		}
 	case Celebration:
+		if cm.summary != nil {
+			// Wait for the player to dismiss the summary.
+			break
+		}
 		// Celebrate for a time ...
 		cm.celebrations += elapsed
 		if cm.celebrations > time.Second*2 {
 			cm.celebrations = 0
-			cm.mgr.AddComponent(cm.mgr.NewEntity(), &game.DiagonalMatrixWipe{
-				W: cm.screenW, H: cm.screenH,
-				Obscuring: true,
-				OnComplete: func() {
-					cc := game.CombatConcluded{
-						Results: map[ecs.Entity]game.CombatResult{},
-					}
-					for _, squadEntity := range cm.squads {
-						squad := cm.mgr.Component(squadEntity, "Squad").(*game.Squad)
-						cc.Results[squadEntity] = game.Defeated
-						for _, e1 := range cm.mgr.Get([]string{"Participant"}) {
-							participant := cm.mgr.Component(e1, "Participant").(*Participant)
-
-							for _, e2 := range squad.Members {
-								if participant.Character == e2 {
-									participant := cm.mgr.Component(e1, "Participant").(*Participant)
-									if participant.Status == Alive {
-										cc.Results[squadEntity] = game.Victorious
-										goto nextSquad
-									} else if participant.Status == Escaped {
-										cc.Results[squadEntity] = game.Escaped
-									}
-
-								}
-							}
-						}
-					nextSquad:
-					}
-					cm.bus.Publish(&cc)
-					for _, e := range cm.mgr.Get([]string{"Token", "Position"}) {
-						if !cm.mgr.HasTag(e, "player") {
-							continue
-						}
-						p := cm.mgr.Component(e, "Position").(*game.Position)
-						cm.bus.Publish(&game.SomethingInteresting{
-							X: p.Center.X,
-							Y: p.Center.Y,
-						})
-						break
-					}
-				},
-			})
+			cm.summary = cm.summarise(cm.results())
+			cm.bus.Publish(&CombatSummarised{Summary: cm.summary})
 		}
 	}
 
 	cm.intents.Update()
-	cm.performances.Update(elapsed)
+	cm.performances.Update(scaled)
 	cm.hud.Update(elapsed)
 	cm.cursors.Update(elapsed)
 }
 
+// MousePressed is the way to notify the Combat that the mouse button has been
+// pressed at a position.
+func (cm *Manager) MousePressed(x, y int) {
+	if ctx, ok := cm.state.(*deployingState); ok {
+		wx, wy := cm.camera.ScreenToWorld(x, y)
+		cm.handleDeployPress(ctx, wx, wy)
+	}
+}
+
 // MousePosition is the way to notify the Combat that the mouse has a new
 // position.
 func (cm *Manager) MousePosition(x, y int) {
@@ -879,8 +949,7 @@ func (cm *Manager) syncParticipantObstacle(evt *ParticipantMovementConcluded) {
 	obstacle := cm.mgr.Component(evt.Entity, "Obstacle").(*game.Obstacle)
 	position := cm.mgr.Component(evt.Entity, "Position").(*game.Position)
 
-	h := cm.field.At(position.Center.X, position.Center.Y)
-	k := h.Key()
+	k := footprintAnchor(cm.field, obstacle.Footprint, position.Center.X, position.Center.Y)
 	obstacle.M = k.M
 	obstacle.N = k.N
 }
@@ -912,9 +981,43 @@ func (cm *Manager) handleCancelSkillRequested(event.Typer) {
 	cm.setState(AwaitingInputState)
 }
 
+// handleUndoRequested takes back the last move of the Participant whose turn
+// it is, if it revealed nothing.
+func (cm *Manager) handleUndoRequested(event.Typer) {
+	if cm.state.Value() != AwaitingInputState {
+		return
+	}
+	if cm.us.Undo() {
+		cm.MousePosition(cm.x, cm.y)
+	}
+}
+
 func (cm *Manager) handleAttemptingEscape(t event.Typer) {
 	ev := t.(*AttemptingEscape)
+	if cm.state.Value() != AwaitingInputState || ev.Entity != cm.turnToken {
+		return
+	}
 	participant := cm.mgr.Component(ev.Entity, "Participant").(*Participant)
+	if !canEscapeFrom(cm.mgr, ev.Entity) || participant.ActionPoints.Cur < escapeCost {
+		return
+	}
+
+	participant.ActionPoints.Cur -= escapeCost
+	cm.bus.Publish(&StatModified{
+		Entity: ev.Entity,
+		Stat:   game.ActionStat,
+		Amount: -escapeCost,
+	})
+
+	chance := escapeChance(cm.mgr, ev.Entity)
+	if rand.Float64() >= chance {
+		cm.bus.Publish(&EscapeFailed{
+			Entity: ev.Entity,
+			Chance: chance,
+		})
+		return
+	}
+
 	participant.Status = Escaped
 
 	cm.mgr.RemoveComponent(ev.Entity, &game.Sprite{})
@@ -928,6 +1031,37 @@ func (cm *Manager) handleAttemptingEscape(t event.Typer) {
 	cm.setState(PreparingState)
 }
 
+// handleSummaryDismissed wipes away the combat once the player has seen the
+// summary of it, and announces the results.
+func (cm *Manager) handleSummaryDismissed(event.Typer) {
+	if cm.summary == nil {
+		return
+	}
+	cm.mgr.AddComponent(cm.mgr.NewEntity(), &game.DiagonalMatrixWipe{
+		W: cm.screenW, H: cm.screenH,
+		Obscuring: true,
+		OnComplete: func() {
+			cc := game.CombatConcluded{
+				Results: cm.results(),
+			}
+			cc.Progression = cm.progression(cc.Results)
+			cc.LeftBehind = cm.leftBehind(cc.Results)
+			cm.bus.Publish(&cc)
+			for _, e := range cm.mgr.Get([]string{"Token", "Position"}) {
+				if !cm.mgr.HasTag(e, "player") {
+					continue
+				}
+				p := cm.mgr.Component(e, "Position").(*game.Position)
+				cm.bus.Publish(&game.SomethingInteresting{
+					X: p.Center.X,
+					Y: p.Center.Y,
+				})
+				break
+			}
+		},
+	})
+}
+
 func (cm *Manager) handleWindowSizeChanged(e event.Typer) {
 	wsc := e.(*game.WindowSizeChanged)
 	cm.screenW, cm.screenH = wsc.NewW, wsc.NewH
@@ -936,19 +1070,12 @@ func (cm *Manager) handleWindowSizeChanged(e event.Typer) {
 func (cm *Manager) handleSkillRequested(e event.Typer) {
 	evt := e.(*SkillRequested)
 
-	// Can we afford this skill?
+	// Can we use this skill?
 	participant := cm.mgr.Component(cm.turnToken, "Participant").(*Participant)
 	s := cm.archive.Skill(evt.Code)
-	for ty, amount := range s.Costs {
-		switch ty {
-		case skill.CostsActionPoints:
-			if participant.ActionPoints.Cur < amount {
-				// TODO: Let the UI know the player can't afford it.
-				return
-			}
-		default:
-			panic(fmt.Sprintf("combat.Manager.handleSkillRequested: Costs type not implemented %T", ty))
-		}
+	if !participant.SkillAvailability(s).Usable() {
+		// TODO: Let the UI know the player can't use it.
+		return
 	}
 
 	cm.setState(&selectingTargetState{
@@ -963,14 +1090,33 @@ func (cm *Manager) handleSkillUseConcluded(e event.Typer) {
 func (cm *Manager) handleCharacterEnteredCombat(et event.Typer) {
 	evt := et.(*CharacterEnteredCombat)
 
-	// FIXME: evt.Profession should be used to retrieve a recipe
-	recipe := baddy.Recipes[baddy.Skellington]
+	id, ok := baddy.ForProfession(evt.Profession)
+	if !ok {
+		panic(fmt.Sprintf("no baddy recipe for profession %q", evt.Profession))
+	}
+	char := baddy.Recipes[id].Construct(nil)
+	char.Baddy = id.String()
+	char.Level = max(evt.Level, 1)
+	char.Disambiguator = rand.Float64()
+	char.CurrentHealth = stats.Derive(char, cm.archive.Profession(char.Profession), nil).MaxHealth
+
+	team := evt.Team
+	if team == nil {
+		team = game.NewTeam()
+		team.Control = game.ComputerControl
+		apps := cm.archive.PedestalAppearances(true)
+		team.PedestalAppearance = apps[rand.Intn(len(apps))]
+	}
 
-	// FIXME: evt.Level should be used to Construct the recipe.
-	char := recipe.Construct(nil)
+	// The Character of a summons only exists for the duration of the combat.
 	e := cm.mgr.NewEntity()
+	cm.mgr.Tag(e, "combat")
 	cm.mgr.AddComponent(e, char)
-	cm.createParticipation(e, evt.Team, cm.field.Get(evt.At))
+	participant := cm.createParticipation(e, team, cm.field.Get(evt.At))
+	cm.mgr.AddComponent(participant, &Summoned{
+		Summoner: evt.Summoner,
+		Lifetime: evt.Lifetime,
+	})
 }
 
 func (cm *Manager) handleParticipantDefiled(et event.Typer) {
@@ -984,8 +1130,9 @@ func (cm *Manager) handleParticipantMoving(event.Typer) {
 	participants := []geom.Key{}
 	for _, e := range cm.mgr.Get([]string{"Participant", "Position"}) {
 		pos := cm.mgr.Component(e, "Position").(*game.Position)
-		k := cm.field.Wtok(pos.Center.X, pos.Center.Y)
-		participants = append(participants, k)
+		footprint := footprintOf(cm.mgr, e)
+		k := footprintAnchor(cm.field, footprint, pos.Center.X, pos.Center.Y)
+		participants = append(participants, footprint.Keys(k)...)
 	}
 
 	// Go through every "vanisher" (a tile that obscures the view of other
@@ -22,6 +22,14 @@ type HUDData struct {
 	TurnQueue []QueuedParticipant
 
 	Skills [7]UISkillInfoRow
+
+	// Preview of how the attack being confirmed is modified by the direction
+	// it approaches its target from.
+	Preview string
+
+	// Undoable is set when the last move can be taken back.
+	Undoable   bool
+	HandleUndo func(string)
 }
 
 type QueuedParticipant struct {
@@ -36,8 +44,17 @@ type QueuedParticipant struct {
 	OverlayFrameY int
 
 	Prep, PrepMax int
+
+	// Elapsed is the preparation that is expected to pass before this turn.
+	Elapsed int
 }
 
+// timelineLength is how many upcoming turns the turn order timeline shows.
+const timelineLength = 12
+
+// logLines is how many entries of the combat log the log panel shows.
+const logLines = 8
+
 func (qp QueuedParticipant) PrepPercent() int {
 	return int(float64(qp.Prep) / float64(qp.PrepMax) * 26)
 }
@@ -51,4 +68,14 @@ type UISkillInfo struct {
 	IconY   int
 	Id      string
 	Handle  func(string)
+	Hover   func(string)
+
+	// Disabled skills are shown, but cannot be selected, because the
+	// Participant cannot afford them, they are cooling down, or they have no
+	// charges left.
+	Disabled bool
+
+	// Remaining is overlaid on a disabled skill to show how many turns and how
+	// much preparation remain of its cooldown.
+	Remaining string
 }
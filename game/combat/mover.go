@@ -6,6 +6,9 @@ import (
 
 type Waypoint struct {
 	X, Y float64
+
+	// Cost is the total ActionPoints spent to reach this Waypoint.
+	Cost int
 }
 
 // Mover is a component that can move.
@@ -15,6 +18,9 @@ type Mover struct {
 	Elapsed  time.Duration // Elapsed time since started the move to the next Hex.
 	Speed    float64       // Speed is how fast we're moving
 
+	// Halted Movers wait where they are, while something interrupts them.
+	Halted bool
+
 	// Delta to next Hex.
 	dx, dy float64
 
@@ -33,19 +33,32 @@ func (nav *Navigator) Update(mgr *ecs.World, elapsed time.Duration) {
 	entities := mgr.Get([]string{"Mover", "Participant", "Position"})
 
 	for _, e := range entities {
-		mover := mgr.Component(e, "Mover").(*Mover)
-		pos := mgr.Component(e, "Position").(*game.Position)
-		facer := mgr.Component(e, "Facer").(*game.Facer)
-		participant := mgr.Component(e, "Participant").(*Participant)
+		nav.advance(mgr, e, elapsed)
+	}
+}
 
-		oldFace := facer.Face
+// advance the Mover e by elapsed. No move is ever overshot, however much time
+// has elapsed; time left over at the end of a move is spent on the next one.
+func (nav *Navigator) advance(mgr *ecs.World, e ecs.Entity, elapsed time.Duration) {
+	pos := mgr.Component(e, "Position").(*game.Position)
+	facer := mgr.Component(e, "Facer").(*game.Facer)
+	participant := mgr.Component(e, "Participant").(*Participant)
 
-		speed := float64(250 * time.Millisecond)
-		if t, ok := professionSpeeds[participant.Profession]; ok {
-			speed = float64(t)
+	speed := float64(250 * time.Millisecond)
+	if t, ok := professionSpeeds[participant.Profession]; ok {
+		speed = float64(t)
+	}
+
+	for {
+		mover, ok := mgr.Component(e, "Mover").(*Mover)
+		if !ok || mover.Halted {
+			return
 		}
 
-		if len(mover.Moves) > 1 && mover.Moves[0].X == pos.Center.X && mover.Moves[0].Y == pos.Center.Y {
+		oldFace := facer.Face
+
+		// A Mover that has not started yet has no Duration.
+		if mover.Duration == 0 && len(mover.Moves) > 1 && mover.Moves[0].X == pos.Center.X && mover.Moves[0].Y == pos.Center.Y {
 			// Pop the first move, because it's the current position.
 			mover.Moves = mover.Moves[1:]
 
@@ -63,6 +76,7 @@ func (nav *Navigator) Update(mgr *ecs.World, elapsed time.Duration) {
 			if dir, err := direction(mover.dx, mover.dy); err == nil {
 				facer.Face = dir
 			}
+			nav.publishLeaving(e, mover)
 			if oldFace != facer.Face || mover.Speed != 0 {
 				nav.Publish(&ParticipantMoving{
 					Entity:    e,
@@ -78,6 +92,13 @@ func (nav *Navigator) Update(mgr *ecs.World, elapsed time.Duration) {
 			dest := mover.Moves[0]
 			pos.Center.X = float64(dest.X)
 			pos.Center.Y = float64(dest.Y)
+			nav.Publish(&ParticipantEnteredHex{
+				Entity: e,
+				FromX:  mover.x,
+				FromY:  mover.y,
+				ToX:    pos.Center.X,
+				ToY:    pos.Center.Y,
+			})
 
 			// Pop the move list to update the next destination.
 			mover.Moves = mover.Moves[1:]
@@ -93,7 +114,7 @@ func (nav *Navigator) Update(mgr *ecs.World, elapsed time.Duration) {
 				})
 				mgr.RemoveComponent(e, mover)
 				nav.Publish(&ParticipantMovementConcluded{Entity: e})
-				continue
+				return
 			}
 
 			oldSpeed := mover.Speed
@@ -118,6 +139,7 @@ func (nav *Navigator) Update(mgr *ecs.World, elapsed time.Duration) {
 			if dir, err := direction(mover.dx, mover.dy); err == nil {
 				facer.Face = dir
 			}
+			nav.publishLeaving(e, mover)
 			if oldFace != facer.Face || oldSpeed != mover.Speed {
 				nav.Publish(&ParticipantMoving{
 					Entity:    e,
@@ -129,15 +151,32 @@ func (nav *Navigator) Update(mgr *ecs.World, elapsed time.Duration) {
 			}
 
 		} else {
-			// Traversing ....
-			mover.Elapsed += elapsed
+			// Traversing, but no further than the end of this move.
+			step := min(elapsed, mover.Duration-mover.Elapsed)
+			mover.Elapsed += step
+			elapsed -= step
 
 			pos.Center.X = mover.Elapsed.Seconds()/mover.Duration.Seconds()*mover.dx + mover.x
 			pos.Center.Y = mover.Elapsed.Seconds()/mover.Duration.Seconds()*mover.dy + mover.y
+
+			if elapsed <= 0 {
+				return
+			}
 		}
 	}
 }
 
+// publishLeaving announces the move that a Mover is starting.
+func (nav *Navigator) publishLeaving(e ecs.Entity, mover *Mover) {
+	nav.Publish(&ParticipantLeavingHex{
+		Entity: e,
+		FromX:  mover.x,
+		FromY:  mover.y,
+		ToX:    mover.Moves[0].X,
+		ToY:    mover.Moves[0].Y,
+	})
+}
+
 // direction calculates which hexagonal direction the vector of x,y aligns with.
 // FIXME: hard-coded assumption about hexes being 24 by 16
 func direction(x, y float64) (geom.DirectionType, error) {
@@ -0,0 +1,81 @@
+package combat
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+)
+
+func TestNavigatorClampsLargeElapsed(t *testing.T) {
+	mgr := ecs.NewWorld()
+	bus := &event.Bus{}
+	nav := NewNavigator(bus)
+
+	path := []Waypoint{{X: 0, Y: 0}, {X: 17, Y: 8}, {X: 34, Y: 16}, {X: 34, Y: 32}, {X: 51, Y: 24}}
+	e := mgr.NewEntity()
+	mgr.AddComponent(e, &Participant{Status: Alive})
+	mgr.AddComponent(e, &game.Position{})
+	mgr.AddComponent(e, &game.Facer{})
+	mgr.AddComponent(e, &Mover{Moves: append([]Waypoint{}, path...)})
+	pos := mgr.Component(e, "Position").(*game.Position)
+
+	// onPath reports whether x,y lies on the segment from a to b.
+	onPath := func(x, y float64) bool {
+		for i := 1; i < len(path); i++ {
+			a, b := path[i-1], path[i]
+			cross := (b.X-a.X)*(y-a.Y) - (b.Y-a.Y)*(x-a.X)
+			within := math.Min(a.X, b.X)-1e-9 <= x && x <= math.Max(a.X, b.X)+1e-9 &&
+				math.Min(a.Y, b.Y)-1e-9 <= y && y <= math.Max(a.Y, b.Y)+1e-9
+			if math.Abs(cross) < 1e-6 && within {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Every move starts from where the last one finished.
+	entered := []Waypoint{}
+	bus.Subscribe(ParticipantEnteredHex{}.Type(), func(t event.Typer) {
+		ev := t.(*ParticipantEnteredHex)
+		entered = append(entered, Waypoint{X: ev.ToX, Y: ev.ToY})
+	})
+	bus.Subscribe(ParticipantLeavingHex{}.Type(), func(ev event.Typer) {
+		leaving := ev.(*ParticipantLeavingHex)
+		if leaving.FromX != pos.Center.X || leaving.FromY != pos.Center.Y {
+			t.Errorf("want to leave from %v,%v, got %v,%v", pos.Center.X, pos.Center.Y, leaving.FromX, leaving.FromY)
+		}
+	})
+	concluded := false
+	bus.Subscribe(ParticipantMovementConcluded{}.Type(), func(event.Typer) {
+		concluded = true
+	})
+
+	// A frame is longer than any single move, as when skipping animations.
+	for i := 0; i < 20 && !concluded; i++ {
+		nav.Update(mgr, 700*time.Millisecond)
+		if !onPath(pos.Center.X, pos.Center.Y) {
+			t.Fatalf("frame %d: %v,%v is off the path", i, pos.Center.X, pos.Center.Y)
+		}
+	}
+	if !concluded {
+		t.Fatalf("want the movement concluded")
+	}
+	if len(entered) != len(path)-1 {
+		t.Errorf("want every hex entered, got %v", entered)
+	}
+	if end := path[len(path)-1]; pos.Center.X != end.X || pos.Center.Y != end.Y {
+		t.Errorf("want to finish at %v, got %v,%v", end, pos.Center.X, pos.Center.Y)
+	}
+
+	// Even an enormous frame does not leave the path.
+	mgr.AddComponent(e, &Mover{Moves: []Waypoint{{X: 51, Y: 24}, {X: 34, Y: 32}}})
+	path = []Waypoint{{X: 51, Y: 24}, {X: 34, Y: 32}}
+	nav.Update(mgr, 1000*time.Second)
+	if pos.Center.X != 34 || pos.Center.Y != 32 {
+		t.Errorf("want to arrive at 34,32, got %v,%v", pos.Center.X, pos.Center.Y)
+	}
+}
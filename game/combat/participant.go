@@ -2,6 +2,7 @@ package combat
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/griffithsh/squads/ecs"
 	"github.com/griffithsh/squads/game"
@@ -73,9 +74,11 @@ type Participant struct {
 
 	Profession string
 	Sex        game.CharacterSex
+	Traversal  game.Traversal
 
 	PreparationThreshold CurMax
 	ActionPoints         CurMax
+	Energy               CurMax
 	BaseHealth           int
 	CurrentHealth        int
 
@@ -86,6 +89,18 @@ type Participant struct {
 
 	Status EngagementStatus // Alive, Knocked down, or Escaped
 
+	// energyRemainder is the regeneration that has accumulated from
+	// preparation, but that has not yet been rounded up into a whole unit of
+	// Energy.
+	energyRemainder int
+
+	// cooldowns of skills the Participant has used that have not yet elapsed.
+	cooldowns map[skill.ID]*skill.Cooldown
+
+	// skillUses counts how many times each skill has been used in this
+	// combat.
+	skillUses map[skill.ID]int
+
 	// Injuries stores the current Injuries the Participant is suffering from.
 	Injuries map[skill.InjuryType]*injury
 
@@ -162,8 +177,103 @@ func (p *Participant) baseDamage() (baseMin, baseMax float64) {
 }
 
 func (p *Participant) maxHealth() int {
-	// TODO: Also include vitality and raw health from equipped items.
-	return game.MaxHealth(p.BaseHealth, p.Vitality)
+	return game.MaxHealth(p.BaseHealth+int(p.ItemStats[item.HealthModifier]), p.Vitality)
+}
+
+// Participants regenerate energyRegenPercent of their maximum Energy for every
+// energyRegenPreparation of preparation that elapses.
+const (
+	energyRegenPercent     = 10
+	energyRegenPreparation = 1000
+)
+
+// regenerateEnergy restores Energy to the Participant in proportion to the
+// preparation that has elapsed, and returns how much Energy was restored.
+func (p *Participant) regenerateEnergy(elapsedPreparation int) int {
+	if p.Energy.Cur >= p.Energy.Max {
+		p.energyRemainder = 0
+		return 0
+	}
+
+	const unit = 100 * energyRegenPreparation
+	p.energyRemainder += elapsedPreparation * p.Energy.Max * energyRegenPercent
+	gained := p.energyRemainder / unit
+	p.energyRemainder -= gained * unit
+
+	if p.Energy.Cur+gained > p.Energy.Max {
+		gained = p.Energy.Max - p.Energy.Cur
+	}
+	p.Energy.Cur += gained
+	return gained
+}
+
+// costOf converts an amount of a skill's CostType into the concrete amount of
+// the resource it consumes from this Participant.
+func (p *Participant) costOf(ty skill.CostType, amount int) int {
+	switch ty {
+	case skill.CostsActionPoints, skill.CostsMana, skill.CostsPreparation:
+		return amount
+	case skill.CostsExhaustionPercent:
+		return int(math.Ceil(float64(p.Energy.Max*amount) / 100))
+	case skill.CostsHealthSacrificePercent:
+		return int(math.Ceil(float64(p.maxHealth()*amount) / 100))
+	default:
+		panic(fmt.Sprintf("unknown cost type %v", ty))
+	}
+}
+
+// canAfford determines whether the Participant has the resources to pay all of
+// the costs. Sacrificing health cannot knock the Participant down, so the cost
+// must be strictly less than the Participant's current health.
+func (p *Participant) canAfford(costs map[skill.CostType]int) bool {
+	for ty, amount := range costs {
+		cost := p.costOf(ty, amount)
+		switch ty {
+		case skill.CostsActionPoints:
+			if p.ActionPoints.Cur < cost {
+				return false
+			}
+		case skill.CostsMana, skill.CostsExhaustionPercent:
+			if p.Energy.Cur < cost {
+				return false
+			}
+		case skill.CostsHealthSacrificePercent:
+			if p.CurrentHealth <= cost {
+				return false
+			}
+		}
+	}
+
+	// Mana and exhaustion are both paid from Energy, so they must be
+	// affordable together as well as individually.
+	energy := p.costOf(skill.CostsMana, costs[skill.CostsMana]) + p.costOf(skill.CostsExhaustionPercent, costs[skill.CostsExhaustionPercent])
+	return p.Energy.Cur >= energy
+}
+
+// payCosts deducts the costs from the Participant, and returns how much each
+// stat was reduced by.
+func (p *Participant) payCosts(costs map[skill.CostType]int) map[game.StatType]int {
+	paid := map[game.StatType]int{}
+	for ty, amount := range costs {
+		cost := p.costOf(ty, amount)
+		switch ty {
+		case skill.CostsActionPoints:
+			p.ActionPoints.Cur -= cost
+			paid[game.ActionStat] += cost
+		case skill.CostsMana, skill.CostsExhaustionPercent:
+			p.Energy.Cur -= cost
+			paid[game.EnergyStat] += cost
+		case skill.CostsHealthSacrificePercent:
+			p.CurrentHealth -= cost
+			paid[game.HPStat] += cost
+		case skill.CostsPreparation:
+			// Preparation is reset at the start of the turn, so the cost is
+			// carried into the preparation for the next turn.
+			p.PreparationThreshold.Cur -= cost
+			paid[game.PrepStat] += cost
+		}
+	}
+	return paid
 }
 
 func (p *Participant) chanceToHit() float64 {
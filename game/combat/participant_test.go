@@ -0,0 +1,88 @@
+package combat
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/griffithsh/squads/skill"
+)
+
+func TestRegenerateEnergy(t *testing.T) {
+	p := Participant{Energy: CurMax{Cur: 0, Max: 50}}
+
+	// 10% of 50 is 5 energy per 1000 preparation, so 1000 preparation split
+	// into awkward increments should still regenerate exactly 5.
+	total := 0
+	for _, inc := range []int{333, 333, 333, 1} {
+		total += p.regenerateEnergy(inc)
+	}
+	if total != 5 || p.Energy.Cur != 5 {
+		t.Errorf("want 5 energy regenerated, got %d (cur %d)", total, p.Energy.Cur)
+	}
+
+	// Regeneration does not exceed the maximum.
+	p.Energy.Cur = 49
+	if got := p.regenerateEnergy(10000); got != 1 {
+		t.Errorf("want 1 energy regenerated at the cap, got %d", got)
+	}
+	if p.Energy.Cur != p.Energy.Max {
+		t.Errorf("want energy at max, got %d/%d", p.Energy.Cur, p.Energy.Max)
+	}
+}
+
+func TestCanAfford(t *testing.T) {
+	for i, tc := range []struct {
+		costs map[skill.CostType]int
+		want  bool
+	}{
+		{map[skill.CostType]int{}, true},
+		{map[skill.CostType]int{skill.CostsActionPoints: 100}, true},
+		{map[skill.CostType]int{skill.CostsActionPoints: 101}, false},
+		{map[skill.CostType]int{skill.CostsMana: 20}, true},
+		{map[skill.CostType]int{skill.CostsMana: 21}, false},
+		{map[skill.CostType]int{skill.CostsExhaustionPercent: 50}, true},
+		{map[skill.CostType]int{skill.CostsExhaustionPercent: 51}, false},
+		{map[skill.CostType]int{skill.CostsMana: 10, skill.CostsExhaustionPercent: 25}, true},
+		{map[skill.CostType]int{skill.CostsMana: 11, skill.CostsExhaustionPercent: 25}, false},
+		// Max health is 25 + 75, and current health is 30.
+		{map[skill.CostType]int{skill.CostsHealthSacrificePercent: 29}, true},
+		{map[skill.CostType]int{skill.CostsHealthSacrificePercent: 30}, false},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			p := Participant{
+				ActionPoints:  CurMax{Cur: 100, Max: 100},
+				Energy:        CurMax{Cur: 20, Max: 40},
+				BaseHealth:    75,
+				CurrentHealth: 30,
+			}
+			if got := p.canAfford(tc.costs); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPayCosts(t *testing.T) {
+	p := Participant{
+		ActionPoints:  CurMax{Cur: 100, Max: 100},
+		Energy:        CurMax{Cur: 20, Max: 40},
+		BaseHealth:    75,
+		CurrentHealth: 30,
+	}
+	p.payCosts(map[skill.CostType]int{
+		skill.CostsActionPoints:           40,
+		skill.CostsMana:                   5,
+		skill.CostsExhaustionPercent:      10,
+		skill.CostsHealthSacrificePercent: 15,
+	})
+
+	if p.ActionPoints.Cur != 60 {
+		t.Errorf("want 60 AP, got %d", p.ActionPoints.Cur)
+	}
+	if p.Energy.Cur != 11 {
+		t.Errorf("want 11 energy, got %d", p.Energy.Cur)
+	}
+	if p.CurrentHealth != 15 {
+		t.Errorf("want 15 health, got %d", p.CurrentHealth)
+	}
+}
@@ -0,0 +1,119 @@
+package combat
+
+import (
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/item"
+	"github.com/griffithsh/squads/skill"
+)
+
+// masteryVariables are the variables that skills can reference in their
+// effects to scale with the user's Masteries.
+var masteryVariables = map[string]game.Mastery{
+	"$SHORT-RANGE-MELEE": game.ShortRangeMeleeMastery,
+	"$LONG-RANGE-MELEE":  game.LongRangeMeleeMastery,
+	"$RANGED-COMBAT":     game.RangedCombatMastery,
+	"$CRAFTS":            game.CraftsmanshipMastery,
+	"$FIRE":              game.FireMastery,
+	"$WATER":             game.WaterMastery,
+	"$EARTH":             game.EarthMastery,
+	"$AIR":               game.AirMastery,
+	"$LIGHTNING":         game.LightningMastery,
+	"$DARK":              game.DarkMastery,
+	"$LIGHT":             game.LightMastery,
+}
+
+// weaponMasteries are the Masteries trained by attacking with each class of
+// weapon.
+var weaponMasteries = map[item.Class]game.Mastery{
+	item.UnarmedClass: game.ShortRangeMeleeMastery,
+	item.SwordClass:   game.ShortRangeMeleeMastery,
+	item.AxeClass:     game.ShortRangeMeleeMastery,
+	item.ClubClass:    game.ShortRangeMeleeMastery,
+	item.DaggerClass:  game.ShortRangeMeleeMastery,
+	item.SlingClass:   game.RangedCombatMastery,
+	item.BowClass:     game.RangedCombatMastery,
+	item.SpearClass:   game.LongRangeMeleeMastery,
+	item.PolearmClass: game.LongRangeMeleeMastery,
+}
+
+// masteriesTrainedBy a skill are the Masteries that its effects scale with,
+// and for attacks, the Mastery of the weapon that the user has equipped.
+func masteriesTrainedBy(s *skill.Description, weapon item.Class) map[game.Mastery]struct{} {
+	result := map[game.Mastery]struct{}{}
+	if s.IsAttack() {
+		if m, ok := weaponMasteries[weapon]; ok {
+			result[m] = struct{}{}
+		}
+	}
+	for _, effect := range s.Effects {
+		for _, what := range effect.What {
+			dmg, ok := what.(skill.DamageEffect)
+			if !ok {
+				continue
+			}
+			for _, op := range append(dmg.Min, dmg.Max...) {
+				if m, ok := masteryVariables[op.Variable]; ok {
+					result[m] = struct{}{}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// progression calculates what each Character in a victorious squad has earned
+// during the combat. Experience is awarded for every opponent that was knocked
+// down or defiled, and each use of a skill counts towards the Masteries it
+// trains.
+func (cm *Manager) progression(results map[ecs.Entity]game.CombatResult) map[ecs.Entity]game.Progression {
+	participants := map[ecs.Entity]ecs.Entity{}
+	for _, e := range cm.mgr.Get([]string{"Participant"}) {
+		participant := cm.mgr.Component(e, "Participant").(*Participant)
+		participants[participant.Character] = e
+	}
+
+	progression := map[ecs.Entity]game.Progression{}
+	for squadEntity, result := range results {
+		if result != game.Victorious {
+			continue
+		}
+		squad := cm.mgr.Component(squadEntity, "Squad").(*game.Squad)
+		for _, charEntity := range squad.Members {
+			e, ok := participants[charEntity]
+			if !ok {
+				continue
+			}
+			participant := cm.mgr.Component(e, "Participant").(*Participant)
+			if participant.Status == Defiled {
+				continue
+			}
+			team := cm.mgr.Component(e, "Team").(*game.Team)
+
+			p := game.Progression{
+				MasteryUses: map[game.Mastery]int{},
+			}
+			for _, other := range cm.mgr.Get([]string{"Participant", "Team"}) {
+				if cm.mgr.Component(other, "Team").(*game.Team).ID == team.ID {
+					continue
+				}
+				if _, summoned := cm.mgr.Component(other, "Summoned").(*Summoned); summoned {
+					// Summons are worth nothing.
+					continue
+				}
+				opponent := cm.mgr.Component(other, "Participant").(*Participant)
+				if opponent.Status != KnockedDown && opponent.Status != Defiled {
+					continue
+				}
+				p.Experience += game.ExperienceForDefeating(participant.Level, opponent.Level)
+			}
+			for id, uses := range participant.skillUses {
+				for m := range masteriesTrainedBy(cm.archive.Skill(id), participant.EquippedWeaponClass) {
+					p.MasteryUses[m] += uses
+				}
+			}
+			progression[charEntity] = p
+		}
+	}
+	return progression
+}
@@ -0,0 +1,218 @@
+package combat
+
+import (
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+	"github.com/griffithsh/squads/skill"
+)
+
+// reactionSystem lets Participants use skills out of turn when their enemies
+// provoke them. Each Participant can react once between its turns, and
+// anything that is moving waits for the reactions to it to finish.
+type reactionSystem struct {
+	mgr     *ecs.World
+	bus     *event.Bus
+	field   *geom.Field
+	archive SkillArchive
+
+	turnToken ecs.Entity
+
+	// reacted are the Participants that have reacted since their last turn.
+	reacted map[ecs.Entity]bool
+
+	// reacting are the Participants whose reactions are executing.
+	reacting map[ecs.Entity]bool
+
+	// halted are the Movers waiting for the reactions to finish.
+	halted []ecs.Entity
+}
+
+func newReactionSystem(mgr *ecs.World, bus *event.Bus, field *geom.Field, archive SkillArchive) *reactionSystem {
+	rs := reactionSystem{
+		mgr:      mgr,
+		bus:      bus,
+		field:    field,
+		archive:  archive,
+		reacted:  map[ecs.Entity]bool{},
+		reacting: map[ecs.Entity]bool{},
+	}
+	bus.Subscribe(game.CombatBegan{}.Type(), rs.handleCombatBegan)
+	bus.Subscribe(ParticipantTurnChanged{}.Type(), rs.handleParticipantTurnChanged)
+	bus.Subscribe(ParticipantLeavingHex{}.Type(), rs.handleParticipantLeavingHex)
+	bus.Subscribe(ParticipantEnteredHex{}.Type(), rs.handleParticipantEnteredHex)
+	bus.Subscribe(DamageAccepted{}.Type(), rs.handleDamageAccepted)
+	bus.Subscribe(ReactionConcluded{}.Type(), rs.handleReactionConcluded)
+
+	return &rs
+}
+
+func (rs *reactionSystem) handleCombatBegan(event.Typer) {
+	rs.turnToken = 0
+	rs.reacted = map[ecs.Entity]bool{}
+	rs.reacting = map[ecs.Entity]bool{}
+	rs.halted = rs.halted[:0]
+}
+
+func (rs *reactionSystem) handleParticipantTurnChanged(t event.Typer) {
+	ev := t.(*ParticipantTurnChanged)
+	rs.turnToken = ev.Entity
+	delete(rs.reacted, ev.Entity)
+}
+
+// handleParticipantLeavingHex triggers opportunity reactions from the enemies
+// that the mover is stepping away from.
+func (rs *reactionSystem) handleParticipantLeavingHex(t event.Typer) {
+	ev := t.(*ParticipantLeavingHex)
+	footprint := footprintOf(rs.mgr, ev.Entity)
+	fromAnchor := footprintAnchor(rs.field, footprint, ev.FromX, ev.FromY)
+	from := footprint.Keys(fromAnchor)
+	to := footprint.Keys(footprintAnchor(rs.field, footprint, ev.ToX, ev.ToY))
+	rs.standAt(ev.Entity, fromAnchor)
+
+	for _, r := range rs.reactors(ev.Entity) {
+		reactor := rs.mgr.Component(r, "Obstacle").(*game.Obstacle).Keys()
+		if keysDistance(reactor, from) > 1 || keysDistance(reactor, to) <= 1 {
+			continue
+		}
+		rs.react(r, skill.OpportunityReaction, ev.Entity, fromAnchor)
+	}
+}
+
+// handleParticipantEnteredHex triggers overwatch reactions from the enemies
+// that can target the hex the mover has entered.
+func (rs *reactionSystem) handleParticipantEnteredHex(t event.Typer) {
+	ev := t.(*ParticipantEnteredHex)
+	footprint := footprintOf(rs.mgr, ev.Entity)
+	at := footprintAnchor(rs.field, footprint, ev.ToX, ev.ToY)
+	rs.standAt(ev.Entity, at)
+
+	for _, r := range rs.reactors(ev.Entity) {
+		rs.react(r, skill.OverwatchReaction, ev.Entity, at)
+	}
+}
+
+// handleDamageAccepted triggers a counter reaction from a Participant that is
+// hit in melee.
+func (rs *reactionSystem) handleDamageAccepted(t event.Typer) {
+	ev := t.(*DamageAccepted)
+	if ev.Source == 0 || ev.SkillType != skill.Attack || rs.reacting[ev.Source] {
+		// Reactions cannot be countered.
+		return
+	}
+	source, ok := rs.mgr.Component(ev.Source, "Obstacle").(*game.Obstacle)
+	if !ok || !containsEntity(rs.reactors(ev.Source), ev.Target) {
+		return
+	}
+	target := rs.mgr.Component(ev.Target, "Obstacle").(*game.Obstacle)
+	if footprintDistance(source, target) > 1 {
+		return
+	}
+	rs.react(ev.Target, skill.CounterReaction, ev.Source, geom.Key{M: source.M, N: source.N})
+}
+
+func (rs *reactionSystem) handleReactionConcluded(t event.Typer) {
+	ev := t.(*ReactionConcluded)
+	delete(rs.reacting, ev.Entity)
+	if len(rs.reacting) > 0 {
+		return
+	}
+
+	for _, e := range rs.halted {
+		mover, ok := rs.mgr.Component(e, "Mover").(*Mover)
+		if !ok {
+			continue
+		}
+		mover.Halted = false
+
+		// A Participant that has been knocked down goes no further.
+		if rs.mgr.Component(e, "Participant").(*Participant).Status != Alive {
+			stopMover(mover, rs.mgr.Component(e, "Position").(*game.Position))
+		}
+	}
+	rs.halted = rs.halted[:0]
+}
+
+// reactors finds the Participants that could react to the provocation of the
+// Participant e.
+func (rs *reactionSystem) reactors(e ecs.Entity) []ecs.Entity {
+	provoker, ok := rs.mgr.Component(e, "Team").(*game.Team)
+	if !ok {
+		return nil
+	}
+	result := []ecs.Entity{}
+	for _, r := range rs.mgr.Get([]string{"Participant", "Team", "Obstacle"}) {
+		participant := rs.mgr.Component(r, "Participant").(*Participant)
+		switch {
+		case participant.Status != Alive,
+			r == rs.turnToken,
+			rs.reacted[r],
+			rs.mgr.Component(r, "Team").(*game.Team).ID == provoker.ID:
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// react uses the first skill of the reactor r that is triggered by reaction
+// and can target k, against the Participant target.
+func (rs *reactionSystem) react(r ecs.Entity, reaction skill.Reaction, target ecs.Entity, k geom.Key) {
+	participant := rs.mgr.Component(r, "Participant").(*Participant)
+	for _, id := range participant.Skills {
+		s := rs.archive.Skill(id)
+		if s.Reaction != reaction || !participant.SkillAvailability(s).Usable() {
+			continue
+		}
+		if !CanTarget(rs.mgr, rs.field, r, s, k) {
+			continue
+		}
+
+		rs.reacted[r] = true
+		rs.reacting[r] = true
+		rs.halt(target)
+
+		_, _, origin := executeTargeting(rs.mgr, r, &s.Targeting, k)
+		if s.IsAttack() && origin != k {
+			rs.mgr.AddComponent(r, &game.Facer{Face: geom.FindDirection(origin, k)})
+		}
+		rs.bus.Publish(&UsingSkill{
+			User:     r,
+			Skill:    s.ID,
+			Selected: rs.field.Get(k),
+			Reaction: true,
+		})
+		return
+	}
+}
+
+// halt stops the target in its tracks, if it is moving, so that the reaction
+// can be resolved before it moves on.
+func (rs *reactionSystem) halt(target ecs.Entity) {
+	mover, ok := rs.mgr.Component(target, "Mover").(*Mover)
+	if !ok {
+		return
+	}
+	mover.Halted = true
+	if !containsEntity(rs.halted, target) {
+		rs.halted = append(rs.halted, target)
+	}
+}
+
+// standAt moves the Obstacle of the moving Participant e to k. The Obstacle of
+// a Mover is otherwise only synchronised with its Position once it has
+// arrived, but reactions must find it where it stands.
+func (rs *reactionSystem) standAt(e ecs.Entity, k geom.Key) {
+	if obstacle, ok := rs.mgr.Component(e, "Obstacle").(*game.Obstacle); ok {
+		obstacle.M, obstacle.N = k.M, k.N
+	}
+}
+
+// stopMover ends the movement of a Mover where it stands.
+func stopMover(mover *Mover, pos *game.Position) {
+	mover.Moves = []Waypoint{{X: pos.Center.X, Y: pos.Center.Y}}
+	mover.x, mover.y = pos.Center.X, pos.Center.Y
+	mover.dx, mover.dy = 0, 0
+	mover.Elapsed = mover.Duration
+}
@@ -0,0 +1,123 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/approach"
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/item"
+	"github.com/griffithsh/squads/geom"
+	"github.com/griffithsh/squads/skill"
+	"github.com/griffithsh/squads/targeting"
+)
+
+type testArchive map[skill.ID]*skill.Description
+
+func (a testArchive) Skill(id skill.ID) *skill.Description               { return a[id] }
+func (testArchive) SkillsByProfession(string) []*skill.Description       { return nil }
+func (testArchive) SkillsByWeaponClass(item.Class) []*skill.Description  { return nil }
+func (testArchive) Profession(profession string) *game.ProfessionDetails { return nil }
+func (testArchive) Appearance(string, game.CharacterSex, string, string) *game.Appearance {
+	return nil
+}
+func (testArchive) ApproachModifiers() approach.Table {
+	return approach.Table{Default: approach.Modifiers{Side: noApproachModifier, Rear: noApproachModifier}}
+}
+
+func TestReactions(t *testing.T) {
+	field := newTestField(t, 8, 8)
+	mgr := ecs.NewWorld()
+	bus := &event.Bus{}
+
+	melee := targeting.Rule{
+		Selectable: targeting.Selectable{
+			Type:     targeting.SelectWithin,
+			MinRange: 1,
+			MaxRange: 1,
+			Filters:  []targeting.TargetFilter{targeting.TargetEnemy},
+		},
+		Brush: targeting.Brush{Type: targeting.SingleHex},
+	}
+	archive := testArchive{
+		"opportunity": {ID: "opportunity", Tags: []skill.Classification{skill.Attack}, Targeting: melee, Reaction: skill.OpportunityReaction},
+		"counter":     {ID: "counter", Tags: []skill.Classification{skill.Attack}, Targeting: melee, Reaction: skill.CounterReaction},
+	}
+	newReactionSystem(mgr, bus, field, archive)
+
+	participate := func(team int64, k geom.Key, skills ...skill.ID) ecs.Entity {
+		e := mgr.NewEntity()
+		mgr.AddComponent(e, &Participant{Status: Alive, Skills: skills})
+		mgr.AddComponent(e, &game.Team{ID: team})
+		mgr.AddComponent(e, &game.Obstacle{M: k.M, N: k.N, ObstacleType: game.CharacterObstacle, Footprint: game.SmallFootprint})
+		mgr.AddComponent(e, &game.Facer{})
+		x, y := field.Ktow(k)
+		mgr.AddComponent(e, &game.Position{Center: game.Center{X: x, Y: y}})
+		return e
+	}
+	guard := participate(1, geom.Key{M: 3, N: 3}, "opportunity", "counter")
+	mover := participate(2, geom.Key{M: 3, N: 4})
+	bus.Publish(&ParticipantTurnChanged{Entity: mover})
+
+	used := []*UsingSkill{}
+	bus.Subscribe(UsingSkill{}.Type(), func(t event.Typer) {
+		used = append(used, t.(*UsingSkill))
+	})
+
+	step := func(from, to geom.Key) {
+		fx, fy := field.Ktow(from)
+		tx, ty := field.Ktow(to)
+		mgr.AddComponent(mover, &Mover{Moves: []Waypoint{{X: tx, Y: ty}}})
+		bus.Publish(&ParticipantLeavingHex{Entity: mover, FromX: fx, FromY: fy, ToX: tx, ToY: ty})
+	}
+
+	// Moving while staying adjacent does not provoke.
+	step(geom.Key{M: 3, N: 4}, geom.Key{M: 4, N: 3})
+	if len(used) != 0 {
+		t.Fatalf("want no reaction while staying adjacent, got %v", used)
+	}
+
+	// Moving away does.
+	step(geom.Key{M: 3, N: 4}, geom.Key{M: 3, N: 5})
+	if len(used) != 1 {
+		t.Fatalf("want an opportunity reaction, got %d reactions", len(used))
+	}
+	if ev := used[0]; ev.User != guard || ev.Skill != "opportunity" || !ev.Reaction || ev.Selected.Key() != (geom.Key{M: 3, N: 4}) {
+		t.Errorf("unexpected reaction %+v", ev)
+	}
+	m := mgr.Component(mover, "Mover").(*Mover)
+	if !m.Halted {
+		t.Errorf("want the mover halted while the reaction resolves")
+	}
+
+	// Only one reaction is allowed between turns, so the guard cannot
+	// counter.
+	bus.Publish(&DamageAccepted{Target: guard, Source: mover, SkillType: skill.Attack})
+	if len(used) != 1 {
+		t.Errorf("want no more reactions, got %d", len(used))
+	}
+
+	// The mover is knocked down by the reaction, and goes no further.
+	mgr.Component(mover, "Participant").(*Participant).Status = KnockedDown
+	bus.Publish(&ReactionConcluded{Entity: guard})
+	if m.Halted {
+		t.Errorf("want the mover to resume")
+	}
+	if len(m.Moves) != 1 || m.Elapsed < m.Duration {
+		t.Errorf("want the mover stopped where it stands, got %+v", m)
+	}
+
+	// Once the guard's turn comes around, it can react again.
+	mgr.Component(mover, "Participant").(*Participant).Status = Alive
+	bus.Publish(&ParticipantTurnChanged{Entity: guard})
+	bus.Publish(&ParticipantTurnChanged{Entity: mover})
+	bus.Publish(&DamageAccepted{Target: guard, Source: mover, SkillType: skill.Spell})
+	if len(used) != 1 {
+		t.Errorf("want spells not countered, got %d reactions", len(used))
+	}
+	bus.Publish(&DamageAccepted{Target: guard, Source: mover, SkillType: skill.Attack})
+	if len(used) != 2 || used[1].Skill != "counter" || used[1].User != guard {
+		t.Fatalf("want a counter reaction, got %v", used)
+	}
+}
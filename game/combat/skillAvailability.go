@@ -0,0 +1,78 @@
+package combat
+
+import "github.com/griffithsh/squads/skill"
+
+// SkillAvailability describes whether a Participant is able to use a skill
+// right now, and if not, what is preventing it.
+type SkillAvailability struct {
+	// Affordable is false when the Participant cannot pay the costs of the
+	// skill.
+	Affordable bool
+
+	// Cooldown is what remains of the skill's cooldown.
+	Cooldown skill.Cooldown
+
+	// Charges remaining for the skill in this combat, or -1 when the skill
+	// has unlimited charges.
+	Charges int
+}
+
+// Usable returns whether the skill can be used right now.
+func (a SkillAvailability) Usable() bool {
+	return a.Affordable && a.Cooldown.Elapsed() && a.Charges != 0
+}
+
+// SkillAvailability reports whether the Participant can use a skill. The HUD
+// uses it to disable skill buttons, and AI controllers should use it to decide
+// which skills they may choose from.
+func (p *Participant) SkillAvailability(s *skill.Description) SkillAvailability {
+	result := SkillAvailability{
+		Affordable: p.canAfford(s.Costs),
+		Charges:    -1,
+	}
+	if cd, ok := p.cooldowns[s.ID]; ok {
+		result.Cooldown = *cd
+	}
+	if s.Charges > 0 {
+		result.Charges = s.Charges - p.skillUses[s.ID]
+	}
+	return result
+}
+
+// recordSkillUse starts the cooldown of a skill and consumes one of its
+// charges. The use is also counted towards the masteries the skill trains.
+func (p *Participant) recordSkillUse(s *skill.Description) {
+	if !s.Cooldown.Elapsed() {
+		if p.cooldowns == nil {
+			p.cooldowns = map[skill.ID]*skill.Cooldown{}
+		}
+		cd := s.Cooldown
+		p.cooldowns[s.ID] = &cd
+	}
+	if p.skillUses == nil {
+		p.skillUses = map[skill.ID]int{}
+	}
+	p.skillUses[s.ID]++
+}
+
+// prepareCooldowns reduces the preparation remaining on the Participant's
+// cooldowns.
+func (p *Participant) prepareCooldowns(preparation int) {
+	for id, cd := range p.cooldowns {
+		cd.Preparation -= preparation
+		if cd.Elapsed() {
+			delete(p.cooldowns, id)
+		}
+	}
+}
+
+// beginTurnCooldowns reduces the turns remaining on the Participant's
+// cooldowns. It should be called when the Participant's turn begins.
+func (p *Participant) beginTurnCooldowns() {
+	for id, cd := range p.cooldowns {
+		cd.Turns--
+		if cd.Elapsed() {
+			delete(p.cooldowns, id)
+		}
+	}
+}
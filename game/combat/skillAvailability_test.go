@@ -0,0 +1,66 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/skill"
+)
+
+func TestSkillAvailability(t *testing.T) {
+	s := &skill.Description{
+		ID:       "test-skill",
+		Cooldown: skill.Cooldown{Turns: 2, Preparation: 500},
+		Charges:  2,
+	}
+	p := Participant{}
+
+	if a := p.SkillAvailability(s); !a.Usable() || a.Charges != 2 {
+		t.Fatalf("want usable with 2 charges before first use, got %+v", a)
+	}
+
+	p.recordSkillUse(s)
+	if a := p.SkillAvailability(s); a.Usable() || a.Charges != 1 {
+		t.Fatalf("want unusable with 1 charge after use, got %+v", a)
+	}
+
+	// Both the turns and the preparation must elapse.
+	p.beginTurnCooldowns()
+	p.beginTurnCooldowns()
+	if a := p.SkillAvailability(s); a.Usable() || a.Cooldown.Preparation != 500 {
+		t.Fatalf("want unusable while preparation remains, got %+v", a)
+	}
+	p.prepareCooldowns(499)
+	if p.SkillAvailability(s).Usable() {
+		t.Fatalf("want unusable with 1 preparation remaining")
+	}
+	p.prepareCooldowns(1)
+	if !p.SkillAvailability(s).Usable() {
+		t.Fatalf("want usable once cooldown has elapsed")
+	}
+
+	// Using the last charge makes it unusable regardless of cooldown.
+	p.recordSkillUse(s)
+	p.beginTurnCooldowns()
+	p.beginTurnCooldowns()
+	p.prepareCooldowns(500)
+	if a := p.SkillAvailability(s); a.Usable() || a.Charges != 0 {
+		t.Fatalf("want unusable with no charges, got %+v", a)
+	}
+}
+
+func TestCooldownLabel(t *testing.T) {
+	for _, tc := range []struct {
+		cd   skill.Cooldown
+		want string
+	}{
+		{skill.Cooldown{}, ""},
+		{skill.Cooldown{Turns: 2}, "2"},
+		{skill.Cooldown{Preparation: 500}, "500p"},
+		{skill.Cooldown{Turns: 2, Preparation: 500}, "2 500p"},
+		{skill.Cooldown{Turns: -1, Preparation: 250}, "250p"},
+	} {
+		if got := cooldownLabel(tc.cd); got != tc.want {
+			t.Errorf("%+v: want %q, got %q", tc.cd, tc.want, got)
+		}
+	}
+}
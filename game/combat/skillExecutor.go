@@ -54,6 +54,10 @@ type skillExecutor struct {
 	archive SkillArchive
 
 	inPlay []*skillExecutionContext
+
+	// concluded is set when a skill used on its user's turn has finished, but
+	// reactions to it are still in play.
+	concluded bool
 }
 
 func newSkillExecutor(mgr *ecs.World, bus *event.Bus, field *geom.Field, archive SkillArchive) *skillExecutor {
@@ -80,7 +84,7 @@ type skillExecutionContext struct {
 	effects  []effect
 	affected []ecs.Entity
 	targeted []geom.Key
-	missCalc func() bool
+	missCalc func(target ecs.Entity) bool
 }
 
 // determineAffected collects the Entities that the usage of this skill affects,
@@ -90,9 +94,7 @@ type skillExecutionContext struct {
 func (se *skillExecutor) determineAffected(ev *UsingSkill, s *skill.Description) ([]ecs.Entity, []geom.Key) {
 	affected := []ecs.Entity{}
 
-	user := se.mgr.Component(ev.User, "Obstacle").(*game.Obstacle)
-	origin := geom.Key{M: user.M, N: user.N}
-	_, painted := s.Targeting.Execute(ev.Selected.Key(), origin)
+	_, painted, _ := executeTargeting(se.mgr, ev.User, &s.Targeting, ev.Selected.Key())
 
 	for _, e := range se.mgr.Get([]string{"Participant"}) {
 		// Defiled Participants do not have an Obstacle.
@@ -100,8 +102,9 @@ func (se *skillExecutor) determineAffected(ev *UsingSkill, s *skill.Description)
 		if !exists {
 			continue
 		}
+		// Participants are affected when any hex they occupy is painted.
 		for _, k := range painted {
-			if k.M == o.M && k.N == o.N {
+			if o.Occupies(k) {
 				affected = append(affected, e)
 				break
 			}
@@ -129,7 +132,7 @@ func (se *skillExecutor) handleUsingSkill(t event.Typer) {
 	// if the skill is tagged Attack, then we need to apply the chance to hit
 	// modifier to the base chance to hit. Spells do not miss and ignore the
 	// chance to hit modifier.
-	missCalc := func() bool {
+	missCalc := func(ecs.Entity) bool {
 		return false
 	}
 	if s.IsAttack() {
@@ -137,12 +140,18 @@ func (se *skillExecutor) handleUsingSkill(t event.Typer) {
 		baseChance := usingParticipant.chanceToHit()
 
 		chance := baseChance + ((1.0 - baseChance) * s.AttackChanceToHitModifier)
-		if chance > 1.0 {
-			chance = 1.0
-		} else if chance < 0 {
-			chance = 0
-		}
-		missCalc = func() bool {
+		missCalc = func(target ecs.Entity) bool {
+			// Attacks on the sides and rear of the target are more likely to
+			// hit.
+			_, approach := attackApproach(se.mgr, se.archive.ApproachModifiers(), ev.User, target)
+			chance := chance + ((1.0 - chance) * approach.ChanceToHit)
+			// Targets in cover are harder to hit.
+			chance *= 1.0 - coverOf(se.mgr, target)
+			if chance > 1.0 {
+				chance = 1.0
+			} else if chance < 0 {
+				chance = 0
+			}
 			roll := rand.Float64()
 			return roll > chance
 		}
@@ -172,17 +181,15 @@ func (se *skillExecutor) handleUsingSkill(t event.Typer) {
 		missCalc: missCalc,
 	})
 
-	// Apply costs of skill to user.
+	// Apply costs and cooldown of skill to user.
 	participant := se.mgr.Component(ev.User, "Participant").(*Participant)
-	for ty, amount := range s.Costs {
-		switch ty {
-		case skill.CostsActionPoints:
-			participant.ActionPoints.Cur -= amount
-		case skill.CostsMana:
-			// TODO:
-		default:
-			panic(fmt.Sprintf("skillExector.handleUsingSkill: Cost Type %T not implemented", ty))
-		}
+	participant.recordSkillUse(s)
+	for stat, amount := range participant.payCosts(s.Costs) {
+		se.bus.Publish(&StatModified{
+			Entity: ev.User,
+			Stat:   stat,
+			Amount: -amount,
+		})
 	}
 }
 
@@ -202,32 +209,13 @@ func (se *skillExecutor) dereferencer(e ecs.Entity) func(s string) float64 {
 			case "$DMG-MAX":
 				return max
 
-			// Masteries.
-			case "$SHORT-RANGE-MELEE":
-				return float64(participant.Masteries[game.ShortRangeMeleeMastery])
-			case "$LONG-RANGE-MELEE":
-				return float64(participant.Masteries[game.LongRangeMeleeMastery])
-			case "$RANGED-COMBAT":
-				return float64(participant.Masteries[game.RangedCombatMastery])
-			case "$CRAFTS":
-				return float64(participant.Masteries[game.CraftsmanshipMastery])
-			case "$FIRE":
-				return float64(participant.Masteries[game.FireMastery])
-			case "$WATER":
-				return float64(participant.Masteries[game.WaterMastery])
-			case "$EARTH":
-				return float64(participant.Masteries[game.EarthMastery])
-			case "$AIR":
-				return float64(participant.Masteries[game.AirMastery])
-			case "$LIGHTNING":
-				return float64(participant.Masteries[game.LightningMastery])
-			case "$DARK":
-				return float64(participant.Masteries[game.DarkMastery])
-			case "$LIGHT":
-				return float64(participant.Masteries[game.LightMastery])
-
-			// Code is wrong.
 			default:
+				// Masteries.
+				if m, ok := masteryVariables[s]; ok {
+					return float64(participant.Masteries[m])
+				}
+
+				// Code is wrong.
 				panic(fmt.Sprintf("dereference: unsupported variable \"%s\"", s))
 			}
 		}
@@ -255,18 +243,24 @@ func (se *skillExecutor) executeEffect(effect effect, inPlay *skillExecutionCont
 			}
 
 			for _, affected := range inPlay.affected {
-				if inPlay.missCalc() {
+				if inPlay.missCalc(affected) {
 					se.bus.Publish(&DamageFailed{
 						Target: affected,
 						Reason: "Miss",
 					})
 					continue
 				}
+				amount := dmg
+				if inPlay.desc.IsAttack() {
+					_, approach := attackApproach(se.mgr, se.archive.ApproachModifiers(), inPlay.ev.User, affected)
+					amount = int(float64(dmg) * approach.Damage)
+				}
 				se.bus.Publish(&DamageApplied{
-					Amount:     dmg,
+					Amount:     amount,
 					Target:     affected,
 					DamageType: game.PhysicalDamage,
 					SkillType:  ef.Classification,
+					Source:     inPlay.ev.User,
 				})
 			}
 		case skill.ReviveEffect:
@@ -304,16 +298,18 @@ func (se *skillExecutor) executeEffect(effect effect, inPlay *skillExecutionCont
 		case skill.SpawnParticipantEffect:
 			dereference := se.dereferencer(inPlay.ev.User)
 			for _, key := range inPlay.targeted {
-				// FIXME: When executing a SpawnParticipantEffect, it is assumed
-				// that the new participant is on the same team as the User of the
-				// skill. This assumption might not always hold though.
-				team := se.mgr.Component(inPlay.ev.User, "Team").(*game.Team)
+				var team *game.Team
+				if ef.Team == skill.UserTeam {
+					team = se.mgr.Component(inPlay.ev.User, "Team").(*game.Team)
+				}
 
 				se.bus.Publish(&CharacterEnteredCombat{
 					Level:      ef.Level.Calculate(dereference),
 					Profession: ef.Profession,
 					Team:       team,
 					At:         key,
+					Summoner:   inPlay.ev.User,
+					Lifetime:   ef.Lifetime,
 				})
 			}
 		case skill.InjuryEffect:
@@ -345,7 +341,16 @@ func (se *skillExecutor) Update(elapsed time.Duration) {
 				se.inPlay = append(se.inPlay[:i], se.inPlay[i+1:]...)
 				i--
 
-				if len(se.inPlay) == 0 {
+				if inPlay.ev.Reaction {
+					se.bus.Publish(&ReactionConcluded{Entity: inPlay.ev.User})
+				} else {
+					se.concluded = true
+				}
+
+				// Reactions conclude on their own, and the skill that they
+				// reacted to concludes once they are all finished.
+				if len(se.inPlay) == 0 && se.concluded {
+					se.concluded = false
 					se.bus.Publish(&SkillUseConcluded{
 						// FIXME: We cannot provide these values if we are using multiple
 						// skills in play. Do we even need these values?
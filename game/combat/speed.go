@@ -0,0 +1,134 @@
+package combat
+
+import (
+	"time"
+
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+)
+
+// Speed is how fast a combat plays out.
+type Speed int
+
+const (
+	NormalSpeed Speed = iota
+	DoubleSpeed
+	QuadrupleSpeed
+
+	// SkipAnimations plays out combat so quickly that animations are
+	// finished almost as soon as they begin.
+	SkipAnimations
+)
+
+// instantScale is how much faster than normal the turns of computer
+// controlled Participants are resolved in instant mode.
+const instantScale = 1000
+
+// instantStep is the combat time that each extra step of resolving an action
+// in instant mode advances by, and maxInstantSteps bounds how many steps are
+// taken in a frame.
+const (
+	instantStep     = time.Second
+	maxInstantSteps = 1000
+)
+
+// Scale of time at the Speed.
+func (s Speed) Scale() float64 {
+	switch s {
+	case DoubleSpeed:
+		return 2
+	case QuadrupleSpeed:
+		return 4
+	case SkipAnimations:
+		return 64
+	default:
+		return 1
+	}
+}
+
+// Label of the Speed for presentation to the player.
+func (s Speed) Label() string {
+	switch s {
+	case DoubleSpeed:
+		return "2x"
+	case QuadrupleSpeed:
+		return "4x"
+	case SkipAnimations:
+		return "Skip"
+	default:
+		return "1x"
+	}
+}
+
+// next Speed when cycling through them.
+func (s Speed) next() Speed {
+	if s == SkipAnimations {
+		return NormalSpeed
+	}
+	return s + 1
+}
+
+// instantTurn determines whether instant mode is resolving the turn of a
+// computer controlled Participant. Preparation and the states between turns
+// are never resolved instantly.
+func (cm *Manager) instantTurn() bool {
+	if !cm.instant {
+		return false
+	}
+	switch cm.state.Value() {
+	case Uninitialised, PreparingState, Celebration, FadingIn, FadingOut, DeployingState:
+		return false
+	}
+	team, ok := cm.mgr.Component(cm.turnToken, "Team").(*game.Team)
+	return ok && team.Control != game.LocalControl
+}
+
+// timeScale is how much faster than real time the combat should currently
+// play out.
+func (cm *Manager) timeScale() float64 {
+	if cm.instantTurn() {
+		return instantScale
+	}
+	return cm.speed.Scale()
+}
+
+// scaled converts elapsed real time to elapsed combat time.
+func (cm *Manager) scaled(elapsed time.Duration) time.Duration {
+	return time.Duration(float64(elapsed) * cm.timeScale())
+}
+
+// synchroniseAnimationSpeed makes the animations of the combat keep pace with
+// the Speed.
+func (cm *Manager) synchroniseAnimationSpeed() {
+	scale := cm.timeScale()
+	for _, animated := range []string{"FrameAnimation", "TakeDamageAnimation"} {
+		for _, e := range cm.mgr.Get([]string{animated}) {
+			if !cm.mgr.HasTag(e, "combat") {
+				continue
+			}
+			if speed, ok := cm.mgr.Component(e, "AnimationSpeed").(*game.AnimationSpeed); ok && speed.Speed == scale {
+				continue
+			}
+			cm.mgr.AddComponent(e, &game.AnimationSpeed{Speed: scale})
+		}
+	}
+}
+
+// CycleSpeed changes the Speed of combat to the next fastest, or back to
+// normal from the fastest.
+func (cm *Manager) CycleSpeed() {
+	cm.bus.Publish(&SpeedRequested{Speed: cm.speed.next(), Instant: cm.instant})
+}
+
+// ToggleInstant switches instant mode on or off. In instant mode, the actions
+// of computer controlled Participants are resolved within a frame, without
+// waiting for them to be animated.
+func (cm *Manager) ToggleInstant() {
+	cm.bus.Publish(&SpeedRequested{Speed: cm.speed, Instant: !cm.instant})
+}
+
+func (cm *Manager) handleSpeedRequested(t event.Typer) {
+	ev := t.(*SpeedRequested)
+	cm.speed, cm.instant = ev.Speed, ev.Instant
+	cm.bus.Publish(&SpeedChanged{Speed: cm.speed, Instant: cm.instant})
+}
@@ -0,0 +1,61 @@
+package combat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+)
+
+func TestSpeed(t *testing.T) {
+	mgr := ecs.NewWorld()
+	bus := &event.Bus{}
+	cm := Manager{mgr: mgr, bus: bus, state: PreparingState}
+	bus.Subscribe(SpeedRequested{}.Type(), cm.handleSpeedRequested)
+	changes := []*SpeedChanged{}
+	bus.Subscribe(SpeedChanged{}.Type(), func(t event.Typer) {
+		changes = append(changes, t.(*SpeedChanged))
+	})
+
+	for _, want := range []time.Duration{2 * time.Second, 4 * time.Second, 64 * time.Second, time.Second} {
+		cm.CycleSpeed()
+		if got := cm.scaled(time.Second); got != want {
+			t.Errorf("speed %v: want %v, got %v", cm.speed, want, got)
+		}
+	}
+	if len(changes) != 4 {
+		t.Errorf("want 4 changes, got %d", len(changes))
+	}
+
+	computer := mgr.NewEntity()
+	mgr.AddComponent(computer, &game.Team{Control: game.ComputerControl})
+	mgr.Tag(computer, "combat")
+	mgr.AddComponent(computer, &game.TakeDamageAnimation{})
+	player := mgr.NewEntity()
+	mgr.AddComponent(player, &game.Team{Control: game.LocalControl})
+
+	cm.ToggleInstant()
+	cm.state = ExecutingState
+	cm.turnToken = player
+	if got := cm.timeScale(); got != 1 {
+		t.Errorf("want the player's turn played normally, got %f", got)
+	}
+	cm.turnToken = computer
+	if got := cm.timeScale(); got != instantScale {
+		t.Errorf("want the computer's turn resolved instantly, got %f", got)
+	}
+	cm.synchroniseAnimationSpeed()
+	if speed, ok := mgr.Component(computer, "AnimationSpeed").(*game.AnimationSpeed); !ok || speed.Speed != instantScale {
+		t.Errorf("want animations kept in pace, got %v", speed)
+	}
+	cm.state = AwaitingInputState
+	if got := cm.timeScale(); got != instantScale {
+		t.Errorf("want the computer's choice resolved instantly, got %f", got)
+	}
+	cm.state = PreparingState
+	if got := cm.timeScale(); got != 1 {
+		t.Errorf("want preparation unaffected by instant mode, got %f", got)
+	}
+}
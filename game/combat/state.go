@@ -32,6 +32,9 @@ const (
 	// FadingOut is when the combat is going to another scene, and the curtain
 	// that obscures the scene change is appearing.
 	FadingOut
+	// DeployingState is when the local player is arranging their squad on the
+	// field before the combat begins.
+	DeployingState
 )
 
 // Value allows simple States without context to implement the StateContext
@@ -19,11 +19,12 @@ func _() {
 	_ = x[Celebration-8]
 	_ = x[FadingIn-9]
 	_ = x[FadingOut-10]
+	_ = x[DeployingState-11]
 }
 
-const _State_name = "UninitialisedAwaitingInputStateSelectingPathStateSelectingTargetStateConfirmingSelectedTargetStateExecutingStateThinkingStatePreparingStateCelebrationFadingInFadingOut"
+const _State_name = "UninitialisedAwaitingInputStateSelectingPathStateSelectingTargetStateConfirmingSelectedTargetStateExecutingStateThinkingStatePreparingStateCelebrationFadingInFadingOutDeployingState"
 
-var _State_index = [...]uint8{0, 13, 31, 49, 69, 98, 112, 125, 139, 150, 158, 167}
+var _State_index = [...]uint8{0, 13, 31, 49, 69, 98, 112, 125, 139, 150, 158, 167, 181}
 
 func (i State) String() string {
 	if i < 0 || i >= State(len(_State_index)-1) {
@@ -0,0 +1,92 @@
+package combat
+
+import (
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+)
+
+// CharacterSummary is what one Character of a Squad did in a combat.
+type CharacterSummary struct {
+	Name string
+	Tally
+}
+
+// SquadSummary is the outcome of a combat for one Squad.
+type SquadSummary struct {
+	Squad      ecs.Entity
+	Player     bool
+	Result     game.CombatResult
+	Characters []CharacterSummary
+}
+
+// Summary of a combat that has finished.
+type Summary struct {
+	// Banner announces the outcome for the player.
+	Banner string
+	Squads []SquadSummary
+}
+
+// results determines the outcome of the combat for each Squad. A Squad is
+// Victorious if any of its members is still standing, or otherwise Escaped if
+// any of its members escaped.
+func (cm *Manager) results() map[ecs.Entity]game.CombatResult {
+	results := map[ecs.Entity]game.CombatResult{}
+	for _, squadEntity := range cm.squads {
+		squad := cm.mgr.Component(squadEntity, "Squad").(*game.Squad)
+		results[squadEntity] = game.Defeated
+		for _, e := range cm.mgr.Get([]string{"Participant"}) {
+			participant := cm.mgr.Component(e, "Participant").(*Participant)
+			if !containsEntity(squad.Members, participant.Character) {
+				continue
+			}
+			if participant.Status == Alive {
+				results[squadEntity] = game.Victorious
+				break
+			} else if participant.Status == Escaped {
+				results[squadEntity] = game.Escaped
+			}
+		}
+	}
+	return results
+}
+
+// summarise the outcome of the combat from its results.
+func (cm *Manager) summarise(results map[ecs.Entity]game.CombatResult) *Summary {
+	participants := map[ecs.Entity]ecs.Entity{}
+	for _, e := range cm.mgr.Get([]string{"Participant"}) {
+		participant := cm.mgr.Component(e, "Participant").(*Participant)
+		participants[participant.Character] = e
+	}
+
+	summary := Summary{Banner: "The battle is over"}
+	for _, squadEntity := range cm.squads {
+		ss := SquadSummary{
+			Squad:  squadEntity,
+			Player: cm.mgr.HasTag(squadEntity, "player"),
+			Result: results[squadEntity],
+		}
+		squad := cm.mgr.Component(squadEntity, "Squad").(*game.Squad)
+		for _, charEntity := range squad.Members {
+			e, ok := participants[charEntity]
+			if !ok {
+				continue
+			}
+			ss.Characters = append(ss.Characters, CharacterSummary{
+				Name:  cm.mgr.Component(e, "Participant").(*Participant).Name,
+				Tally: cm.log.Tally(e),
+			})
+		}
+		if ss.Player {
+			switch ss.Result {
+			case game.Victorious:
+				summary.Banner = "Victory!"
+			case game.Defeated:
+				summary.Banner = "Defeat"
+			case game.Escaped:
+				summary.Banner = "Escaped"
+			}
+		}
+		summary.Squads = append(summary.Squads, ss)
+	}
+	return &summary
+}
@@ -0,0 +1,65 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+)
+
+func TestSummarise(t *testing.T) {
+	mgr := ecs.NewWorld()
+	bus := &event.Bus{}
+	cm := Manager{
+		mgr: mgr,
+		log: NewCombatLog(mgr, bus, testArchive{}),
+	}
+
+	participate := func(squad *game.Squad, name string, status EngagementStatus) ecs.Entity {
+		char := mgr.NewEntity()
+		squad.Members = append(squad.Members, char)
+		e := mgr.NewEntity()
+		mgr.AddComponent(e, &Participant{Name: name, Status: status, Character: char})
+		return e
+	}
+	player, players := mgr.NewEntity(), &game.Squad{}
+	mgr.Tag(player, "player")
+	mgr.AddComponent(player, players)
+	enemy, enemies := mgr.NewEntity(), &game.Squad{}
+	mgr.AddComponent(enemy, enemies)
+	cm.squads = []ecs.Entity{player, enemy}
+
+	alice := participate(players, "Alice", Alive)
+	participate(players, "Bob", KnockedDown)
+	wolf := participate(enemies, "Wolf", KnockedDown)
+
+	bus.Publish(&ParticipantTurnChanged{Entity: alice})
+	bus.Publish(&DamageAccepted{Target: wolf, Source: alice, Amount: 30})
+	bus.Publish(&ParticipantDied{Entity: wolf})
+
+	results := cm.results()
+	if results[player] != game.Victorious || results[enemy] != game.Defeated {
+		t.Fatalf("unexpected results %v", results)
+	}
+
+	summary := cm.summarise(results)
+	if summary.Banner != "Victory!" {
+		t.Errorf("want a victory banner, got %q", summary.Banner)
+	}
+	if len(summary.Squads) != 2 || len(summary.Squads[0].Characters) != 2 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	want := Tally{DamageDealt: 30, Kills: 1, Turns: 1}
+	if got := summary.Squads[0].Characters[0]; got.Name != "Alice" || got.Tally != want {
+		t.Errorf("want Alice %+v, got %+v", want, got)
+	}
+	if got := summary.Squads[1].Characters[0].DamageTaken; got != 30 {
+		t.Errorf("want the wolf to have taken 30, got %d", got)
+	}
+
+	mgr.Component(alice, "Participant").(*Participant).Status = Escaped
+	if got := cm.summarise(cm.results()); got.Squads[0].Result != game.Escaped || got.Banner != "Escaped" {
+		t.Errorf("want the player escaped, got %+v", got)
+	}
+}
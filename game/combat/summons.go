@@ -0,0 +1,98 @@
+package combat
+
+import (
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/skill"
+)
+
+// Summoned is a Component of the Participants that were summoned during a
+// combat. Summons are not members of any Squad, so they take no part in the
+// results of the combat.
+type Summoned struct {
+	Summoner ecs.Entity
+	Lifetime skill.Lifetime
+
+	// turns and preparation count how much of its Lifetime the summons has
+	// used.
+	turns       int
+	preparation int
+}
+
+// Type of this Component.
+func (*Summoned) Type() string {
+	return "Summoned"
+}
+
+// expired reports whether the summons has outlived its Lifetime.
+func (s *Summoned) expired() bool {
+	return (s.Lifetime.Turns > 0 && s.turns >= s.Lifetime.Turns) ||
+		(s.Lifetime.Preparation > 0 && s.preparation >= s.Lifetime.Preparation)
+}
+
+// summonSystem crumbles summons when their Lifetime is over, or when their
+// summoner can no longer sustain them.
+type summonSystem struct {
+	mgr *ecs.World
+	bus *event.Bus
+
+	turnToken ecs.Entity
+}
+
+func newSummonSystem(mgr *ecs.World, bus *event.Bus) *summonSystem {
+	ss := summonSystem{
+		mgr: mgr,
+		bus: bus,
+	}
+	bus.Subscribe(ParticipantTurnChanged{}.Type(), ss.handleParticipantTurnChanged)
+	bus.Subscribe(ParticipantDied{}.Type(), ss.handleParticipantDied)
+	return &ss
+}
+
+// handleParticipantTurnChanged counts the turns of summons, and crumbles them
+// once their last turn is over.
+func (ss *summonSystem) handleParticipantTurnChanged(t event.Typer) {
+	ev := t.(*ParticipantTurnChanged)
+	ss.turnToken = ev.Entity
+	if summoned, ok := ss.mgr.Component(ev.Entity, "Summoned").(*Summoned); ok {
+		summoned.turns++
+	}
+	ss.sweep()
+}
+
+func (ss *summonSystem) handleParticipantDied(event.Typer) {
+	ss.sweep()
+}
+
+// Elapse preparation from the Lifetimes of the summons.
+func (ss *summonSystem) Elapse(preparation int) {
+	for _, e := range ss.mgr.Get([]string{"Summoned"}) {
+		ss.mgr.Component(e, "Summoned").(*Summoned).preparation += preparation
+	}
+	ss.sweep()
+}
+
+// sweep crumbles the summons that are expired or whose summoner is no longer
+// standing. A summons is spared until the end of its final turn.
+func (ss *summonSystem) sweep() {
+	for _, e := range ss.mgr.Get([]string{"Summoned", "Participant"}) {
+		participant := ss.mgr.Component(e, "Participant").(*Participant)
+		if participant.Status == Defiled {
+			continue
+		}
+		summoned := ss.mgr.Component(e, "Summoned").(*Summoned)
+		summoner, ok := ss.mgr.Component(summoned.Summoner, "Participant").(*Participant)
+		forsaken := !ok || summoner.Status != Alive
+		if !forsaken && (!summoned.expired() || e == ss.turnToken) {
+			continue
+		}
+		ss.crumble(e)
+	}
+}
+
+// crumble the summons e, so that it can no longer be revived.
+func (ss *summonSystem) crumble(e ecs.Entity) {
+	participant := ss.mgr.Component(e, "Participant").(*Participant)
+	participant.Status = Defiled
+	ss.bus.Publish(&ParticipantDefiled{Entity: e})
+}
@@ -0,0 +1,63 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/skill"
+)
+
+func TestSummons(t *testing.T) {
+	mgr := ecs.NewWorld()
+	bus := &event.Bus{}
+	ss := newSummonSystem(mgr, bus)
+
+	participate := func() ecs.Entity {
+		e := mgr.NewEntity()
+		mgr.AddComponent(e, &Participant{Status: Alive})
+		return e
+	}
+	summon := func(summoner ecs.Entity, lifetime skill.Lifetime) ecs.Entity {
+		e := participate()
+		mgr.AddComponent(e, &Summoned{Summoner: summoner, Lifetime: lifetime})
+		return e
+	}
+	status := func(e ecs.Entity) EngagementStatus {
+		return mgr.Component(e, "Participant").(*Participant).Status
+	}
+
+	necro, other := participate(), participate()
+	forever := summon(necro, skill.Lifetime{})
+	twoTurns := summon(necro, skill.Lifetime{Turns: 2})
+	brief := summon(necro, skill.Lifetime{Preparation: 100})
+
+	ss.Elapse(99)
+	if status(brief) != Alive {
+		t.Errorf("want the summons to last until its preparation is spent")
+	}
+	ss.Elapse(1)
+	if status(brief) != Defiled {
+		t.Errorf("want the summons crumbled once its preparation is spent")
+	}
+
+	bus.Publish(&ParticipantTurnChanged{Entity: twoTurns})
+	bus.Publish(&ParticipantTurnChanged{Entity: other})
+	bus.Publish(&ParticipantTurnChanged{Entity: twoTurns})
+	if status(twoTurns) != Alive {
+		t.Errorf("want the summons to take its last turn")
+	}
+	bus.Publish(&ParticipantTurnChanged{Entity: other})
+	if status(twoTurns) != Defiled {
+		t.Errorf("want the summons crumbled after its last turn")
+	}
+
+	if status(forever) != Alive {
+		t.Fatalf("want an unlimited summons to last")
+	}
+	mgr.Component(necro, "Participant").(*Participant).Status = KnockedDown
+	bus.Publish(&ParticipantDied{Entity: necro})
+	if status(forever) != Defiled {
+		t.Errorf("want the summons crumbled with its summoner")
+	}
+}
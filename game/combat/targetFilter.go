@@ -0,0 +1,109 @@
+package combat
+
+import (
+	"math"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+	"github.com/griffithsh/squads/skill"
+	"github.com/griffithsh/squads/targeting"
+)
+
+// participantsAt finds the Participants that occupy the hex at k. Defiled
+// Participants are included by their Position, even though they no longer have
+// an Obstacle.
+func participantsAt(mgr *ecs.World, field *geom.Field, k geom.Key) []ecs.Entity {
+	result := []ecs.Entity{}
+	for _, e := range mgr.Get([]string{"Participant", "Position"}) {
+		if o, ok := mgr.Component(e, "Obstacle").(*game.Obstacle); ok {
+			if o.Occupies(k) {
+				result = append(result, e)
+			}
+			continue
+		}
+		pos := mgr.Component(e, "Position").(*game.Position)
+		if field.Wtok(pos.Center.X, pos.Center.Y) == k {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// matchesFilters determines whether the hex at k satisfies any of the
+// TargetFilters for the user of a skill. An empty list of filters matches
+// every hex.
+func matchesFilters(mgr *ecs.World, field *geom.Field, user ecs.Entity, k geom.Key, filters []targeting.TargetFilter) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	userTeam := mgr.Component(user, "Team").(*game.Team)
+	occupants := participantsAt(mgr, field, k)
+	obstructed := false
+	for _, e := range mgr.Get([]string{"Obstacle"}) {
+		o := mgr.Component(e, "Obstacle").(*game.Obstacle)
+		if o.Occupies(k) {
+			obstructed = true
+			break
+		}
+	}
+
+	for _, filter := range filters {
+		switch filter {
+		case targeting.TargetSelf:
+			for _, e := range occupants {
+				if e == user {
+					return true
+				}
+			}
+		case targeting.TargetAlly, targeting.TargetEnemy:
+			for _, e := range occupants {
+				if e == user {
+					continue
+				}
+				participant := mgr.Component(e, "Participant").(*Participant)
+				if participant.Status != Alive {
+					continue
+				}
+				team := mgr.Component(e, "Team").(*game.Team)
+				if (team.ID == userTeam.ID) == (filter == targeting.TargetAlly) {
+					return true
+				}
+			}
+		case targeting.TargetKnockedDown, targeting.TargetDefiled:
+			want := KnockedDown
+			if filter == targeting.TargetDefiled {
+				want = Defiled
+			}
+			for _, e := range occupants {
+				if mgr.Component(e, "Participant").(*Participant).Status == want {
+					return true
+				}
+			}
+		case targeting.TargetEmpty:
+			if field.Get(k) != nil && !obstructed && len(occupants) == 0 {
+				return true
+			}
+		case targeting.TargetPassable:
+			cost := CostsFuncFactory(field, mgr, user)
+			if !math.IsInf(cost(k, k), 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CanTarget determines whether the user can target the hex at k with a skill,
+// considering its range, its line of sight and its target filters.
+func CanTarget(mgr *ecs.World, field *geom.Field, user ecs.Entity, s *skill.Description, k geom.Key) bool {
+	ok, _, origin := executeTargeting(mgr, user, &s.Targeting, k)
+	if !ok {
+		return false
+	}
+	if visible, _ := lineOfSight(mgr, origin, k, s.Targeting.Trajectory); !visible {
+		return false
+	}
+	return matchesFilters(mgr, field, user, k, s.Targeting.Selectable.Filters)
+}
@@ -0,0 +1,111 @@
+package combat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/griffithsh/squads/data"
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+	"github.com/griffithsh/squads/targeting"
+)
+
+// placeParticipant adds a Participant of the team to the field at k. Defiled
+// Participants leave no Obstacle behind.
+func placeParticipant(mgr *ecs.World, field *geom.Field, k geom.Key, team *game.Team, status EngagementStatus) ecs.Entity {
+	e := mgr.NewEntity()
+	x, y := field.Ktow(k)
+	mgr.AddComponent(e, &game.Position{Center: game.Center{X: x, Y: y}})
+	mgr.AddComponent(e, team)
+	mgr.AddComponent(e, &Participant{Status: status})
+	mgr.AddComponent(e, &game.Facer{})
+	if status != Defiled {
+		mgr.AddComponent(e, &game.Obstacle{M: k.M, N: k.N, ObstacleType: game.CharacterObstacle})
+	}
+	return e
+}
+
+func TestMatchesFilters(t *testing.T) {
+	field := geom.NewField(hexagonBodyWidth, hexagonWingWidth, hexagonHeight)
+	keys := []geom.Key{}
+	for m := 0; m < 4; m++ {
+		for n := 0; n < 4; n++ {
+			keys = append(keys, geom.Key{M: m, N: n})
+		}
+	}
+	if err := field.Load(keys); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	mgr := ecs.NewWorld()
+	friends, foes := &game.Team{ID: 1}, &game.Team{ID: 2}
+
+	user := placeParticipant(mgr, field, geom.Key{M: 0, N: 0}, friends, Alive)
+	placeParticipant(mgr, field, geom.Key{M: 1, N: 0}, friends, Alive)
+	placeParticipant(mgr, field, geom.Key{M: 2, N: 0}, foes, Alive)
+	placeParticipant(mgr, field, geom.Key{M: 3, N: 0}, foes, KnockedDown)
+	placeParticipant(mgr, field, geom.Key{M: 0, N: 1}, friends, Defiled)
+	tree := mgr.NewEntity()
+	mgr.AddComponent(tree, &game.Obstacle{M: 1, N: 1, ObstacleType: game.TreeObstacle})
+	mud := mgr.NewEntity()
+	mgr.AddComponent(mud, &game.Obstacle{M: 2, N: 1, ObstacleType: game.MudObstacle})
+
+	self, ally, enemy, downed, defiled, trees, muddy, empty := geom.Key{M: 0, N: 0}, geom.Key{M: 1, N: 0}, geom.Key{M: 2, N: 0}, geom.Key{M: 3, N: 0}, geom.Key{M: 0, N: 1}, geom.Key{M: 1, N: 1}, geom.Key{M: 2, N: 1}, geom.Key{M: 3, N: 3}
+	all := []geom.Key{self, ally, enemy, downed, defiled, trees, muddy, empty}
+
+	tests := []struct {
+		filters []targeting.TargetFilter
+		want    []geom.Key
+	}{
+		{nil, all},
+		{[]targeting.TargetFilter{targeting.TargetSelf}, []geom.Key{self}},
+		{[]targeting.TargetFilter{targeting.TargetAlly}, []geom.Key{ally}},
+		{[]targeting.TargetFilter{targeting.TargetEnemy}, []geom.Key{enemy}},
+		{[]targeting.TargetFilter{targeting.TargetKnockedDown}, []geom.Key{downed}},
+		{[]targeting.TargetFilter{targeting.TargetDefiled}, []geom.Key{defiled}},
+		{[]targeting.TargetFilter{targeting.TargetEmpty}, []geom.Key{empty}},
+		{[]targeting.TargetFilter{targeting.TargetPassable}, []geom.Key{self, defiled, muddy, empty}},
+		{[]targeting.TargetFilter{targeting.TargetSelf, targeting.TargetAlly}, []geom.Key{self, ally}},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprint(tc.filters), func(t *testing.T) {
+			for _, k := range all {
+				want := false
+				for _, w := range tc.want {
+					if w == k {
+						want = true
+					}
+				}
+				if got := matchesFilters(mgr, field, user, k, tc.filters); got != want {
+					t.Errorf("%v: want %t, got %t", k, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRaiseSkeletonTargets(t *testing.T) {
+	archive, err := data.NewArchive()
+	if err != nil {
+		t.Fatalf("NewArchive: %v", err)
+	}
+	s := archive.Skill("raise-skeleton")
+
+	field := newTestField(t, 4, 4)
+	mgr := ecs.NewWorld()
+	friends, foes := &game.Team{ID: 1}, &game.Team{ID: 2}
+	user := placeParticipant(mgr, field, geom.Key{M: 0, N: 0}, friends, Alive)
+	placeParticipant(mgr, field, geom.Key{M: 2, N: 0}, foes, Alive)
+	placeParticipant(mgr, field, geom.Key{M: 2, N: 2}, foes, KnockedDown)
+
+	if CanTarget(mgr, field, user, s, geom.Key{M: 3, N: 3}) {
+		t.Errorf("want an empty hex untargetable")
+	}
+	if CanTarget(mgr, field, user, s, geom.Key{M: 2, N: 0}) {
+		t.Errorf("want a standing enemy untargetable")
+	}
+	if !CanTarget(mgr, field, user, s, geom.Key{M: 2, N: 2}) {
+		t.Errorf("want a knocked down enemy targetable")
+	}
+}
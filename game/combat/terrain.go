@@ -0,0 +1,132 @@
+package combat
+
+import (
+	"math"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+)
+
+// climbCost is added to the cost multiplier of moving for every level of
+// elevation climbed.
+const climbCost = 0.5
+
+// hazardDamageTypes are the types of damage dealt by each HazardType.
+var hazardDamageTypes = map[game.HazardType]game.DamageType{
+	game.FireHazard:   game.FireDamage,
+	game.PoisonHazard: game.MagicalDamage,
+	game.SpikesHazard: game.PhysicalDamage,
+}
+
+// terrainByKey collects the Terrain of every hex in the combat.
+func terrainByKey(mgr *ecs.World) map[geom.Key]*game.Terrain {
+	result := map[geom.Key]*game.Terrain{}
+	for _, e := range mgr.Get([]string{"Terrain"}) {
+		t := mgr.Component(e, "Terrain").(*game.Terrain)
+		result[geom.Key{M: t.M, N: t.N}] = t
+	}
+	return result
+}
+
+func elevationOf(terrain map[geom.Key]*game.Terrain, k geom.Key) int {
+	if t, ok := terrain[k]; ok {
+		return t.Elevation
+	}
+	return 0
+}
+
+// coverOf determines how much cover a Participant has from attacks. A
+// Participant that occupies several hexes is only as covered as its most
+// exposed hex.
+func coverOf(mgr *ecs.World, e ecs.Entity) float64 {
+	obstacle, ok := mgr.Component(e, "Obstacle").(*game.Obstacle)
+	if !ok {
+		return 0
+	}
+	terrain := terrainByKey(mgr)
+	cover := math.Inf(0)
+	for _, k := range obstacle.Keys() {
+		c := 0.0
+		if t, ok := terrain[k]; ok {
+			c = t.Cover
+		}
+		cover = math.Min(cover, c)
+	}
+	if math.IsInf(cover, 0) {
+		return 0
+	}
+	return cover
+}
+
+// hazardSystem harms Participants when they enter hexes with Hazards.
+type hazardSystem struct {
+	mgr   *ecs.World
+	bus   *event.Bus
+	field *geom.Field
+}
+
+func newHazardSystem(mgr *ecs.World, bus *event.Bus, field *geom.Field) *hazardSystem {
+	result := hazardSystem{
+		mgr:   mgr,
+		bus:   bus,
+		field: field,
+	}
+	bus.Subscribe(ParticipantEnteredHex{}.Type(), result.handleParticipantEnteredHex)
+
+	return &result
+}
+
+func (hs *hazardSystem) handleParticipantEnteredHex(t event.Typer) {
+	ev := t.(*ParticipantEnteredHex)
+	footprint := footprintOf(hs.mgr, ev.Entity)
+
+	// Only the hexes that the Footprint has newly moved onto are entered.
+	left := footprint.Keys(footprintAnchor(hs.field, footprint, ev.FromX, ev.FromY))
+	entered := footprint.Keys(footprintAnchor(hs.field, footprint, ev.ToX, ev.ToY))
+
+	terrain := terrainByKey(hs.mgr)
+	for _, k := range entered {
+		if containsKey(left, k) {
+			continue
+		}
+		t, ok := terrain[k]
+		if !ok || t.Hazard.Type == game.NoHazard || t.Hazard.Damage <= 0 {
+			continue
+		}
+		hs.bus.Publish(&DamageApplied{
+			Amount:     t.Hazard.Damage,
+			Target:     ev.Entity,
+			DamageType: hazardDamageTypes[t.Hazard.Type],
+			Hazard:     t.Hazard.Type,
+		})
+	}
+
+	// A Participant that has been knocked down by a Hazard goes no further,
+	// and gets back the ActionPoints it paid for the steps it won't take.
+	participant := hs.mgr.Component(ev.Entity, "Participant").(*Participant)
+	mover, ok := hs.mgr.Component(ev.Entity, "Mover").(*Mover)
+	if !ok || participant.Status == Alive || len(mover.Moves) == 0 {
+		return
+	}
+	refund := mover.Moves[len(mover.Moves)-1].Cost - mover.Moves[0].Cost
+	mover.Moves = mover.Moves[:1]
+	if refund > 0 {
+		participant.ActionPoints.Cur += refund
+		hs.bus.Publish(&StatModified{
+			Entity: ev.Entity,
+			Stat:   game.ActionStat,
+			Amount: refund,
+		})
+	}
+}
+
+func containsKey(keys []geom.Key, k geom.Key) bool {
+	for _, key := range keys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
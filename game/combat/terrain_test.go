@@ -0,0 +1,178 @@
+package combat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+)
+
+func TestTerrainCosts(t *testing.T) {
+	field := newTestField(t, 6, 6)
+	mgr := ecs.NewWorld()
+	for _, terrain := range []*game.Terrain{
+		{M: 2, N: 2, TerrainType: game.SwampTerrain},
+		{M: 2, N: 3, TerrainType: game.ForestTerrain, Cost: 3},
+		{M: 3, N: 2, Elevation: 2},
+		{M: 4, N: 2, TerrainType: game.SwampTerrain, Elevation: 2},
+		{M: 4, N: 4, TerrainType: game.SwampTerrain},
+	} {
+		mgr.AddComponent(mgr.NewEntity(), terrain)
+	}
+	tree := mgr.NewEntity()
+	mgr.AddComponent(tree, &game.Obstacle{M: 1, N: 4, ObstacleType: game.TreeObstacle})
+	mud := mgr.NewEntity()
+	mgr.AddComponent(mud, &game.Obstacle{M: 4, N: 4, ObstacleType: game.MudObstacle})
+
+	walker := mgr.NewEntity()
+	mgr.AddComponent(walker, &game.Obstacle{M: 1, N: 1, ObstacleType: game.CharacterObstacle})
+	mgr.AddComponent(walker, &Participant{})
+
+	snake := mgr.NewEntity()
+	mgr.AddComponent(snake, &game.Obstacle{M: 1, N: 1, ObstacleType: game.CharacterObstacle})
+	mgr.AddComponent(snake, &Participant{
+		Traversal: game.Traversal{
+			Terrain: map[game.TerrainType]float64{
+				game.SwampTerrain: 1.0,
+			},
+			Obstacles: map[game.ObstacleType]float64{
+				game.TreeObstacle: 1.0,
+			},
+		},
+	})
+
+	for _, tc := range []struct {
+		name     string
+		e        ecs.Entity
+		from, to geom.Key
+		want     float64
+	}{
+		{"open", walker, geom.Key{M: 1, N: 2}, geom.Key{M: 1, N: 3}, 10},
+		{"swamp", walker, geom.Key{M: 1, N: 2}, geom.Key{M: 2, N: 2}, 20},
+		{"costly forest", walker, geom.Key{M: 1, N: 3}, geom.Key{M: 2, N: 3}, 30},
+		{"uphill", walker, geom.Key{M: 2, N: 1}, geom.Key{M: 3, N: 2}, 20},
+		{"downhill", walker, geom.Key{M: 3, N: 2}, geom.Key{M: 2, N: 1}, 10},
+		{"uphill swamp", walker, geom.Key{M: 2, N: 2}, geom.Key{M: 3, N: 2}, 20},
+		{"level swamp", walker, geom.Key{M: 3, N: 2}, geom.Key{M: 4, N: 2}, 20},
+		{"mud in swamp", walker, geom.Key{M: 4, N: 3}, geom.Key{M: 4, N: 4}, 40},
+		{"tree", walker, geom.Key{M: 1, N: 3}, geom.Key{M: 1, N: 4}, math.Inf(0)},
+		{"snake swamp", snake, geom.Key{M: 1, N: 2}, geom.Key{M: 2, N: 2}, 10},
+		{"snake forest", snake, geom.Key{M: 1, N: 3}, geom.Key{M: 2, N: 3}, 30},
+		{"snake tree", snake, geom.Key{M: 1, N: 3}, geom.Key{M: 1, N: 4}, 10},
+		{"snake mud in swamp", snake, geom.Key{M: 4, N: 3}, geom.Key{M: 4, N: 4}, 20},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cost := CostsFuncFactory(field, mgr, tc.e)
+			if got := cost(tc.from, tc.to); got != tc.want {
+				t.Errorf("want %f, got %f", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCover(t *testing.T) {
+	mgr := ecs.NewWorld()
+	mgr.AddComponent(mgr.NewEntity(), &game.Terrain{M: 2, N: 2, Cover: 0.5})
+	mgr.AddComponent(mgr.NewEntity(), &game.Terrain{M: 3, N: 1, Cover: 0.25})
+
+	hiding := mgr.NewEntity()
+	mgr.AddComponent(hiding, &game.Obstacle{M: 2, N: 2, ObstacleType: game.CharacterObstacle})
+	if got := coverOf(mgr, hiding); got != 0.5 {
+		t.Errorf("want 0.5 cover, got %f", got)
+	}
+
+	// The exposed hex of a Medium Footprint leaves it without cover.
+	wolf := mgr.NewEntity()
+	mgr.AddComponent(wolf, &game.Obstacle{M: 2, N: 2, ObstacleType: game.CharacterObstacle, Footprint: game.MediumFootprint})
+	if got := coverOf(mgr, wolf); got != 0 {
+		t.Errorf("want no cover, got %f", got)
+	}
+}
+
+func TestHazards(t *testing.T) {
+	field := newTestField(t, 6, 6)
+	mgr := ecs.NewWorld()
+	bus := &event.Bus{}
+	newHazardSystem(mgr, bus, field)
+
+	mgr.AddComponent(mgr.NewEntity(), &game.Terrain{M: 2, N: 2, Hazard: game.Hazard{Type: game.FireHazard, Damage: 5}})
+	mgr.AddComponent(mgr.NewEntity(), &game.Terrain{M: 2, N: 3, Hazard: game.Hazard{Type: game.SpikesHazard, Damage: 3}})
+
+	e := mgr.NewEntity()
+	mgr.AddComponent(e, &game.Obstacle{M: 2, N: 1, ObstacleType: game.CharacterObstacle})
+	mgr.AddComponent(e, &Participant{Status: Alive})
+
+	var got []*DamageApplied
+	bus.Subscribe(DamageApplied{}.Type(), func(t event.Typer) {
+		got = append(got, t.(*DamageApplied))
+	})
+
+	enter := func(from, to geom.Key) {
+		fx, fy := field.Ktow(from)
+		tx, ty := field.Ktow(to)
+		bus.Publish(&ParticipantEnteredHex{Entity: e, FromX: fx, FromY: fy, ToX: tx, ToY: ty})
+	}
+	enter(geom.Key{M: 2, N: 1}, geom.Key{M: 2, N: 2})
+	enter(geom.Key{M: 2, N: 2}, geom.Key{M: 2, N: 3})
+	enter(geom.Key{M: 2, N: 3}, geom.Key{M: 2, N: 4})
+
+	if len(got) != 2 {
+		t.Fatalf("want 2 damage applications, got %d", len(got))
+	}
+	if got[0].Amount != 5 || got[0].DamageType != game.FireDamage || got[0].Hazard != game.FireHazard {
+		t.Errorf("want 5 FireDamage from a FireHazard, got %d %v %v", got[0].Amount, got[0].DamageType, got[0].Hazard)
+	}
+	if got[1].Amount != 3 || got[1].DamageType != game.PhysicalDamage {
+		t.Errorf("want 3 PhysicalDamage, got %d %v", got[1].Amount, got[1].DamageType)
+	}
+}
+
+func TestHazardRefundsCutOffSteps(t *testing.T) {
+	field := newTestField(t, 6, 6)
+	mgr := ecs.NewWorld()
+	bus := &event.Bus{}
+	newHazardSystem(mgr, bus, field)
+
+	mgr.AddComponent(mgr.NewEntity(), &game.Terrain{M: 2, N: 2, Hazard: game.Hazard{Type: game.FireHazard, Damage: 5}})
+
+	e := mgr.NewEntity()
+	participant := &Participant{Status: Alive, ActionPoints: CurMax{Cur: 10, Max: 40}}
+	mgr.AddComponent(e, participant)
+	mgr.AddComponent(e, &game.Obstacle{M: 2, N: 1, ObstacleType: game.CharacterObstacle})
+
+	waypoint := func(k geom.Key, cost int) Waypoint {
+		x, y := field.Ktow(k)
+		return Waypoint{X: x, Y: y, Cost: cost}
+	}
+	mover := &Mover{Moves: []Waypoint{
+		waypoint(geom.Key{M: 2, N: 2}, 10),
+		waypoint(geom.Key{M: 2, N: 3}, 20),
+		waypoint(geom.Key{M: 2, N: 4}, 30),
+	}}
+	mgr.AddComponent(e, mover)
+
+	// The fire knocks the Participant down.
+	bus.Subscribe(DamageApplied{}.Type(), func(event.Typer) {
+		participant.Status = KnockedDown
+	})
+	var refunds []*StatModified
+	bus.Subscribe(StatModified{}.Type(), func(t event.Typer) {
+		refunds = append(refunds, t.(*StatModified))
+	})
+
+	fx, fy := field.Ktow(geom.Key{M: 2, N: 1})
+	bus.Publish(&ParticipantEnteredHex{Entity: e, FromX: fx, FromY: fy, ToX: mover.Moves[0].X, ToY: mover.Moves[0].Y})
+
+	if len(mover.Moves) != 1 {
+		t.Errorf("want the move cut off after the fire, got %d moves", len(mover.Moves))
+	}
+	if participant.ActionPoints.Cur != 30 {
+		t.Errorf("want 20 ActionPoints refunded to 30, got %d", participant.ActionPoints.Cur)
+	}
+	if len(refunds) != 1 || refunds[0].Stat != game.ActionStat || refunds[0].Amount != 20 {
+		t.Errorf("want a refund of 20 ActionPoints published, got %v", refunds)
+	}
+}
@@ -0,0 +1,93 @@
+package combat
+
+import (
+	"math"
+
+	"github.com/griffithsh/squads/ecs"
+)
+
+// PredictedTurn is a turn that a Participant is expected to take.
+type PredictedTurn struct {
+	Entity ecs.Entity
+
+	// Elapsed is the preparation that is expected to pass before the turn.
+	Elapsed int
+}
+
+// turnSim is the state of a Participant that determines when it takes its
+// turns.
+type turnSim struct {
+	e             ecs.Entity
+	prep, prepMax int
+	disambiguator float64
+}
+
+// maxPredictionSteps guards against predictions that can never produce
+// enough turns.
+const maxPredictionSteps = 1000
+
+// simulateTurns accumulates preparation in the same way that the Manager
+// does when combat is in PreparingState, and returns the next n turns. It
+// modifies the sims it is passed.
+func simulateTurns(sims []turnSim, n int) []PredictedTurn {
+	result := []PredictedTurn{}
+	elapsed := 0
+	for step := 0; len(result) < n && step < maxPredictionSteps; step++ {
+		increment := math.MaxInt
+		for _, s := range sims {
+			if s.prepMax-s.prep < increment {
+				increment = s.prepMax - s.prep
+			}
+		}
+		if increment == math.MaxInt {
+			// Nobody is left to take a turn.
+			break
+		}
+		if increment < 0 {
+			increment = 0
+		}
+
+		elapsed += increment
+		prepared := -1
+		for i := range sims {
+			s := &sims[i]
+			s.prep += increment
+			if s.prep < s.prepMax {
+				continue
+			}
+			if prepared < 0 || s.disambiguator < sims[prepared].disambiguator {
+				prepared = i
+			}
+		}
+		if prepared < 0 {
+			continue
+		}
+		result = append(result, PredictedTurn{Entity: sims[prepared].e, Elapsed: elapsed})
+		sims[prepared].prep = 0
+	}
+	return result
+}
+
+// PredictTurns predicts who will take the next n turns of the combat. The
+// next turn of current is delayed by delay, which lets the cost of a skill
+// that current might use be previewed.
+func PredictTurns(mgr *ecs.World, current ecs.Entity, delay int, n int) []PredictedTurn {
+	sims := []turnSim{}
+	for _, e := range mgr.Get([]string{"Participant"}) {
+		participant := mgr.Component(e, "Participant").(*Participant)
+		if participant.Status != Alive {
+			continue
+		}
+		s := turnSim{
+			e:             e,
+			prep:          participant.PreparationThreshold.Cur,
+			prepMax:       participant.PreparationThreshold.Max,
+			disambiguator: participant.Disambiguator,
+		}
+		if e == current {
+			s.prep -= delay
+		}
+		sims = append(sims, s)
+	}
+	return simulateTurns(sims, n)
+}
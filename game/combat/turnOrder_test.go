@@ -0,0 +1,109 @@
+package combat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/skill"
+)
+
+func TestPredictTurns(t *testing.T) {
+	entities := func(turns []PredictedTurn) []ecs.Entity {
+		result := []ecs.Entity{}
+		for _, turn := range turns {
+			result = append(result, turn.Entity)
+		}
+		return result
+	}
+
+	t.Run("tie-break", func(t *testing.T) {
+		got := simulateTurns([]turnSim{
+			{e: 1, prepMax: 1000, disambiguator: 0.9},
+			{e: 2, prepMax: 1000, disambiguator: 0.1},
+			{e: 3, prep: 500, prepMax: 2000, disambiguator: 0.5},
+		}, 5)
+		want := []PredictedTurn{
+			{Entity: 2, Elapsed: 1000},
+			{Entity: 1, Elapsed: 1000},
+			{Entity: 3, Elapsed: 1500},
+			{Entity: 2, Elapsed: 2000},
+			{Entity: 1, Elapsed: 2000},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		mgr := ecs.NewWorld()
+		participate := func(prep int, disambiguator float64) ecs.Entity {
+			e := mgr.NewEntity()
+			p := &Participant{Status: Alive, Disambiguator: disambiguator}
+			p.PreparationThreshold.Cur = prep
+			p.PreparationThreshold.Max = 1000
+			mgr.AddComponent(e, p)
+			return e
+		}
+		a := participate(0, 0.1)
+		b := participate(200, 0.5)
+
+		if got, want := entities(PredictTurns(mgr, a, 0, 3)), []ecs.Entity{b, a, b}; !reflect.DeepEqual(got, want) {
+			t.Errorf("without delay: want %v, got %v", want, got)
+		}
+		if got, want := entities(PredictTurns(mgr, a, 1500, 3)), []ecs.Entity{b, b, a}; !reflect.DeepEqual(got, want) {
+			t.Errorf("with delay: want %v, got %v", want, got)
+		}
+		if p := mgr.Component(a, "Participant").(*Participant); p.PreparationThreshold.Cur != 0 {
+			t.Errorf("prediction modified the Participant")
+		}
+	})
+}
+
+func TestHoverPreviewsDelay(t *testing.T) {
+	archive := testArchive{
+		"attack": {ID: "attack"},
+		"ritual": {ID: "ritual", Costs: map[skill.CostType]int{skill.CostsPreparation: 500}},
+	}
+	mgr := ecs.NewWorld()
+	participate := func(prep int, disambiguator float64) ecs.Entity {
+		e := mgr.NewEntity()
+		p := &Participant{Status: Alive, Disambiguator: disambiguator}
+		p.PreparationThreshold.Cur = prep
+		p.PreparationThreshold.Max = 1000
+		mgr.AddComponent(e, p)
+		return e
+	}
+	a := participate(0, 0.1)
+	b := participate(600, 0.5)
+	hud := &HUD{mgr: mgr, archive: archive}
+
+	predict := func() []ecs.Entity {
+		result := []ecs.Entity{}
+		for _, turn := range PredictTurns(mgr, a, hud.previewDelay(), 3) {
+			result = append(result, turn.Entity)
+		}
+		return result
+	}
+
+	if got, want := predict(), []ecs.Entity{b, a, b}; !reflect.DeepEqual(got, want) {
+		t.Errorf("not hovering: want %v, got %v", want, got)
+	}
+
+	// A skill that costs no preparation leaves the timeline alone.
+	hud.hoverSkill("attack")
+	if got, want := predict(), []ecs.Entity{b, a, b}; !reflect.DeepEqual(got, want) {
+		t.Errorf("hovering attack: want %v, got %v", want, got)
+	}
+
+	// The ritual delays the next turn of a behind b's second.
+	hud.hoverSkill("ritual")
+	if got, want := predict(), []ecs.Entity{b, b, a}; !reflect.DeepEqual(got, want) {
+		t.Errorf("hovering ritual: want %v, got %v", want, got)
+	}
+
+	hud.hoverSkill("")
+	if got, want := predict(), []ecs.Entity{b, a, b}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after hovering: want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,125 @@
+package combat
+
+import (
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+)
+
+// standing is how a Participant stood before it moved.
+type standing struct {
+	position     game.Position
+	m, n         int
+	facer        game.Facer
+	actionPoints int
+}
+
+// undoSystem remembers the moves made during a turn, so that they can be
+// taken back. Only moves that revealed nothing can be undone, so anything
+// that reacts to, damages or injures a Participant forgets them.
+type undoSystem struct {
+	mgr *ecs.World
+	bus *event.Bus
+
+	turnToken ecs.Entity
+	moves     []standing
+}
+
+func newUndoSystem(mgr *ecs.World, bus *event.Bus) *undoSystem {
+	us := undoSystem{
+		mgr: mgr,
+		bus: bus,
+	}
+	bus.Subscribe(game.CombatBegan{}.Type(), us.forget)
+	bus.Subscribe(ParticipantTurnChanged{}.Type(), us.handleParticipantTurnChanged)
+	bus.Subscribe(ParticipantMovementConcluded{}.Type(), us.handleParticipantMovementConcluded)
+	bus.Subscribe(UsingSkill{}.Type(), us.forget)
+	bus.Subscribe(DamageAccepted{}.Type(), us.forget)
+	bus.Subscribe(DamageFailed{}.Type(), us.forget)
+	bus.Subscribe(InjuryApplied{}.Type(), us.forget)
+	bus.Subscribe(ParticipantDied{}.Type(), us.forget)
+	bus.Subscribe(EscapeFailed{}.Type(), us.forget)
+	return &us
+}
+
+func (us *undoSystem) handleParticipantTurnChanged(t event.Typer) {
+	ev := t.(*ParticipantTurnChanged)
+	us.turnToken = ev.Entity
+	us.forget(t)
+}
+
+// handleParticipantMovementConcluded forgets a move that went nowhere, so that
+// there is nothing to undo.
+func (us *undoSystem) handleParticipantMovementConcluded(t event.Typer) {
+	ev := t.(*ParticipantMovementConcluded)
+	if ev.Entity != us.turnToken || len(us.moves) == 0 {
+		return
+	}
+	participant := us.mgr.Component(ev.Entity, "Participant").(*Participant)
+	if us.moves[len(us.moves)-1].actionPoints == participant.ActionPoints.Cur {
+		us.moves = us.moves[:len(us.moves)-1]
+		us.changed()
+	}
+}
+
+func (us *undoSystem) forget(event.Typer) {
+	if len(us.moves) == 0 {
+		return
+	}
+	us.moves = us.moves[:0]
+	us.changed()
+}
+
+func (us *undoSystem) changed() {
+	us.bus.Publish(&UndoChanged{Entity: us.turnToken, Moves: len(us.moves)})
+}
+
+// Record how the Participant whose turn it is stands, before it moves.
+func (us *undoSystem) Record(e ecs.Entity) {
+	if e != us.turnToken {
+		return
+	}
+	participant := us.mgr.Component(e, "Participant").(*Participant)
+	obstacle := us.mgr.Component(e, "Obstacle").(*game.Obstacle)
+	s := standing{
+		position:     *us.mgr.Component(e, "Position").(*game.Position),
+		m:            obstacle.M,
+		n:            obstacle.N,
+		actionPoints: participant.ActionPoints.Cur,
+	}
+	if facer, ok := us.mgr.Component(e, "Facer").(*game.Facer); ok {
+		s.facer = *facer
+	}
+	us.moves = append(us.moves, s)
+	us.changed()
+}
+
+// Undo the last move of the Participant whose turn it is, returning it to
+// where it stood and refunding the ActionPoints it spent. Undo reports
+// whether there was a move to undo.
+func (us *undoSystem) Undo() bool {
+	if len(us.moves) == 0 {
+		return false
+	}
+	e := us.turnToken
+	s := us.moves[len(us.moves)-1]
+	us.moves = us.moves[:len(us.moves)-1]
+
+	position := s.position
+	us.mgr.AddComponent(e, &position)
+	obstacle := us.mgr.Component(e, "Obstacle").(*game.Obstacle)
+	obstacle.M, obstacle.N = s.m, s.n
+	facer := s.facer
+	us.mgr.AddComponent(e, &facer)
+
+	participant := us.mgr.Component(e, "Participant").(*Participant)
+	refund := s.actionPoints - participant.ActionPoints.Cur
+	participant.ActionPoints.Cur = s.actionPoints
+	us.bus.Publish(&StatModified{
+		Entity: e,
+		Stat:   game.ActionStat,
+		Amount: refund,
+	})
+	us.changed()
+	return true
+}
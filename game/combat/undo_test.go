@@ -0,0 +1,85 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/geom"
+)
+
+func TestUndo(t *testing.T) {
+	mgr := ecs.NewWorld()
+	bus := &event.Bus{}
+	us := newUndoSystem(mgr, bus)
+
+	e := mgr.NewEntity()
+	participant := &Participant{Status: Alive}
+	participant.ActionPoints.Cur = 100
+	mgr.AddComponent(e, participant)
+	mgr.AddComponent(e, &game.Position{Center: game.Center{X: 10, Y: 20}, Layer: 3})
+	mgr.AddComponent(e, &game.Obstacle{M: 1, N: 2})
+	mgr.AddComponent(e, &game.Facer{Face: geom.S})
+	bus.Publish(&ParticipantTurnChanged{Entity: e})
+
+	undoable := 0
+	bus.Subscribe(UndoChanged{}.Type(), func(t event.Typer) {
+		undoable = t.(*UndoChanged).Moves
+	})
+
+	move := func(x, y float64, m, n, cost int) {
+		us.Record(e)
+		participant.ActionPoints.Cur -= cost
+		mgr.AddComponent(e, &game.Position{Center: game.Center{X: x, Y: y}, Layer: 3})
+		obstacle := mgr.Component(e, "Obstacle").(*game.Obstacle)
+		obstacle.M, obstacle.N = m, n
+		mgr.AddComponent(e, &game.Facer{Face: geom.N})
+		bus.Publish(&ParticipantMovementConcluded{Entity: e})
+	}
+
+	move(30, 40, 2, 3, 25)
+	move(50, 60, 3, 4, 25)
+	if undoable != 2 {
+		t.Fatalf("want 2 moves to undo, got %d", undoable)
+	}
+
+	// A move that went nowhere cannot be undone.
+	move(50, 60, 3, 4, 0)
+	if undoable != 2 {
+		t.Fatalf("want a move that cost nothing forgotten, got %d", undoable)
+	}
+
+	if !us.Undo() || !us.Undo() {
+		t.Fatalf("want both moves undone")
+	}
+	if us.Undo() {
+		t.Errorf("want nothing left to undo")
+	}
+	if got := mgr.Component(e, "Position").(*game.Position); got.Center != (game.Center{X: 10, Y: 20}) || got.Layer != 3 {
+		t.Errorf("want the position restored, got %+v", got)
+	}
+	if got := mgr.Component(e, "Obstacle").(*game.Obstacle); got.M != 1 || got.N != 2 {
+		t.Errorf("want the obstacle restored, got %+v", got)
+	}
+	if got := mgr.Component(e, "Facer").(*game.Facer); got.Face != geom.S {
+		t.Errorf("want the facing restored, got %v", got.Face)
+	}
+	if participant.ActionPoints.Cur != 100 {
+		t.Errorf("want the action points refunded, got %d", participant.ActionPoints.Cur)
+	}
+
+	// Damage reveals something, so the move that led to it stands.
+	move(30, 40, 2, 3, 25)
+	bus.Publish(&DamageAccepted{Target: e, Amount: 1})
+	if undoable != 0 || us.Undo() {
+		t.Errorf("want no undo after damage")
+	}
+
+	// Moves are forgotten between turns.
+	move(50, 60, 3, 4, 25)
+	bus.Publish(&ParticipantTurnChanged{Entity: 0})
+	if us.Undo() {
+		t.Errorf("want no undo in another turn")
+	}
+}
@@ -24,6 +24,13 @@ type CombatMapRecipeHex struct {
 	Position geom.Key
 	Obstacle ObstacleType
 	Visuals  []CombatMapRecipeVisual
+
+	Terrain   TerrainType
+	Cost      float64
+	Elevation int
+	Cover     float64
+	Hazard    Hazard
+	Escape    bool
 }
 type CombatMapRecipe struct {
 	Hexes        []CombatMapRecipeHex
@@ -6,6 +6,7 @@ import (
 
 	"github.com/griffithsh/squads/game"
 	"github.com/griffithsh/squads/game/item"
+	"github.com/griffithsh/squads/game/stats"
 )
 
 type CharacterSheetData struct {
@@ -24,6 +25,12 @@ type CharacterSheetData struct {
 	OverlayFrameY int
 	Prep          int
 	AP            int
+	HP            int
+	EN            int
+	Str           int
+	Agi           int
+	Int           int
+	Vit           int
 	Strlvl        string
 	Agilvl        string
 	Intlvl        string
@@ -46,6 +53,7 @@ func AsCharacterSheetData(char *game.Character, equip *item.Equipment, prof *gam
 	bg := game.PortraitBGBig[char.PortraitBG]
 	overlay := game.PortraitFrameBig[char.PortraitFrame]
 	port := app.BigIcon()
+	attrs := stats.Derive(char, prof, equip)
 	return CharacterSheetData{
 		Name:          char.Name,
 		Profession:    char.Profession,
@@ -60,8 +68,14 @@ func AsCharacterSheetData(char *game.Character, equip *item.Equipment, prof *gam
 		OverlayFrame:  overlay.Texture,
 		OverlayFrameX: overlay.X,
 		OverlayFrameY: overlay.X,
-		Prep:          char.InherantPreparation + prof.Preparation + equip.WeaponPreparation(),
-		AP:            char.InherantActionPoints + prof.ActionPoints + equip.WeaponActionPoints(),
+		Prep:          attrs.Preparation,
+		AP:            attrs.ActionPoints,
+		HP:            attrs.MaxHealth,
+		EN:            attrs.MaxEnergy,
+		Str:           attrs.Strength,
+		Agi:           attrs.Agility,
+		Int:           attrs.Intelligence,
+		Vit:           attrs.Vitality,
 		Strlvl:        fmt.Sprintf("%.2f", char.StrengthPerLevel),
 		Agilvl:        fmt.Sprintf("%.2f", char.AgilityPerLevel),
 		Intlvl:        fmt.Sprintf("%.2f", char.IntelligencePerLevel),
@@ -34,7 +34,14 @@ func (CombatBegan) Type() event.Type {
 
 // CombatConcluded occurs when a Combat is over.
 type CombatConcluded struct {
+	// Results of each Squad in the Combat.
 	Results map[ecs.Entity]CombatResult
+
+	// Progression earned by each Character in the Combat.
+	Progression map[ecs.Entity]Progression
+
+	// LeftBehind are the Knocked Down Characters of Squads that escaped.
+	LeftBehind []ecs.Entity
 }
 
 // Type of the Event.
@@ -42,6 +49,31 @@ func (CombatConcluded) Type() event.Type {
 	return "game.CombatConcluded"
 }
 
+// CharacterLevelledUp occurs when a Character has accumulated enough
+// experience to reach a new level.
+type CharacterLevelledUp struct {
+	Character ecs.Entity
+	Level     int
+}
+
+// Type of the Event.
+func (CharacterLevelledUp) Type() event.Type {
+	return "game.CharacterLevelledUp"
+}
+
+// MasteryImproved occurs when a Character has used skills that train a Mastery
+// often enough to improve it.
+type MasteryImproved struct {
+	Character ecs.Entity
+	Mastery   Mastery
+	Level     int
+}
+
+// Type of the Event.
+func (MasteryImproved) Type() event.Type {
+	return "game.MasteryImproved"
+}
+
 // WindowSizeChanged occurs when the size of the window the game is running in
 // changes.
 type WindowSizeChanged struct {
@@ -0,0 +1,74 @@
+package game
+
+import "sort"
+
+// ExperienceToLevel is how much experience a Character of level must
+// accumulate to reach the next level. Characters below level 1 need as much as
+// a level 1 Character, so that they never level up for nothing.
+func ExperienceToLevel(level int) int {
+	level = max(level, 1)
+	return 50 * level * (level + 1)
+}
+
+// ExperienceForDefeating is the experience awarded to a Character of level
+// victor for defeating an opponent of level defeated. Opponents of a higher
+// level are worth more, and opponents of a lower level are worth less.
+func ExperienceForDefeating(victor, defeated int) int {
+	base := 20 + 10*float64(defeated)
+	scale := 1 + 0.2*float64(defeated-victor)
+	if scale < 0.1 {
+		scale = 0.1
+	} else if scale > 2 {
+		scale = 2
+	}
+	return int(base * scale)
+}
+
+// MasteryUsesToAdvance is how many uses of skills that train a Mastery a
+// Character must make to advance that Mastery beyond level.
+func MasteryUsesToAdvance(level int) int {
+	return 5 * (level + 1)
+}
+
+// Progression is what a Character has earned during a combat.
+type Progression struct {
+	Experience int
+
+	// MasteryUses counts the uses of skills that train each Mastery.
+	MasteryUses map[Mastery]int
+}
+
+// Advance the Character by applying a Progression to them. It returns how many
+// levels the Character gained, and which Masteries improved.
+func (c *Character) Advance(p Progression) (levels int, improved []Mastery) {
+	c.Experience += p.Experience
+	for c.Experience >= ExperienceToLevel(c.Level) {
+		c.Experience -= ExperienceToLevel(c.Level)
+		c.Level++
+		levels++
+	}
+
+	for mastery, uses := range p.MasteryUses {
+		if c.MasteryProgress == nil {
+			c.MasteryProgress = map[Mastery]int{}
+		}
+		if c.Masteries == nil {
+			c.Masteries = map[Mastery]int{}
+		}
+		c.MasteryProgress[mastery] += uses
+		advanced := false
+		for c.MasteryProgress[mastery] >= MasteryUsesToAdvance(c.Masteries[mastery]) {
+			c.MasteryProgress[mastery] -= MasteryUsesToAdvance(c.Masteries[mastery])
+			c.Masteries[mastery]++
+			advanced = true
+		}
+		if advanced {
+			improved = append(improved, mastery)
+		}
+	}
+	sort.Slice(improved, func(i, j int) bool {
+		return improved[i] < improved[j]
+	})
+
+	return levels, improved
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExperienceForDefeating(t *testing.T) {
+	even := ExperienceForDefeating(5, 5)
+	if stronger := ExperienceForDefeating(5, 7); stronger <= even {
+		t.Errorf("want stronger opponents to be worth more than %d, got %d", even, stronger)
+	}
+	if weaker := ExperienceForDefeating(5, 3); weaker >= even {
+		t.Errorf("want weaker opponents to be worth less than %d, got %d", even, weaker)
+	}
+	if trivial := ExperienceForDefeating(50, 1); trivial <= 0 {
+		t.Errorf("want some experience for trivial opponents, got %d", trivial)
+	}
+}
+
+func TestCharacterAdvance(t *testing.T) {
+	c := Character{
+		Level:     1,
+		Masteries: map[Mastery]int{FireMastery: 1},
+	}
+
+	// 100 experience to reach level 2, and 300 to reach level 3.
+	levels, improved := c.Advance(Progression{
+		Experience: 450,
+		MasteryUses: map[Mastery]int{
+			FireMastery:      12,
+			LightningMastery: 5,
+		},
+	})
+
+	if levels != 2 || c.Level != 3 {
+		t.Errorf("want 2 levels gained to level 3, got %d levels to level %d", levels, c.Level)
+	}
+	if c.Experience != 50 {
+		t.Errorf("want 50 experience carried over, got %d", c.Experience)
+	}
+	if want := []Mastery{FireMastery, LightningMastery}; !reflect.DeepEqual(improved, want) {
+		t.Errorf("want %v improved, got %v", want, improved)
+	}
+	// Advancing fire mastery from 1 to 2 takes 10 uses.
+	if c.Masteries[FireMastery] != 2 || c.MasteryProgress[FireMastery] != 2 {
+		t.Errorf("want fire mastery 2 with 2 uses of progress, got %d with %d", c.Masteries[FireMastery], c.MasteryProgress[FireMastery])
+	}
+	if c.Masteries[LightningMastery] != 1 || c.MasteryProgress[LightningMastery] != 0 {
+		t.Errorf("want lightning mastery 1 with no progress, got %d with %d", c.Masteries[LightningMastery], c.MasteryProgress[LightningMastery])
+	}
+}
+
+func TestAdvanceFromLevelZero(t *testing.T) {
+	c := Character{}
+	if levels, _ := c.Advance(Progression{Experience: 99}); levels != 0 || c.Level != 0 {
+		t.Errorf("want no levels gained short of 100 experience, got %d levels to level %d", levels, c.Level)
+	}
+	if levels, _ := c.Advance(Progression{Experience: 1}); levels != 1 || c.Level != 1 || c.Experience != 0 {
+		t.Errorf("want 1 level gained at 100 experience, got %d levels to level %d with %d experience", levels, c.Level, c.Experience)
+	}
+}
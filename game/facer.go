@@ -11,3 +11,12 @@ type Facer struct {
 func (f *Facer) Type() string {
 	return "Facer"
 }
+
+// FacingOf returns the direction that f is facing, or S when there is no
+// Facer.
+func FacingOf(f *Facer) geom.DirectionType {
+	if f == nil {
+		return geom.S
+	}
+	return f.Face
+}
@@ -0,0 +1,34 @@
+package game
+
+import "github.com/griffithsh/squads/geom"
+
+// Footprint enumerates how many hexes something occupies.
+type Footprint int
+
+//go:generate stringer -type=Footprint
+
+const (
+	// SmallFootprint occupies a single hex.
+	SmallFootprint Footprint = iota
+
+	// MediumFootprint occupies three hexes that meet at a point: the anchor,
+	// and the hexes to its NE and SE.
+	MediumFootprint
+
+	// LargeFootprint occupies seven hexes: the anchor and every hex adjacent
+	// to it.
+	LargeFootprint
+)
+
+// Keys returns the Keys that the Footprint occupies when it is anchored at
+// anchor. The anchor is always first.
+func (f Footprint) Keys(anchor geom.Key) []geom.Key {
+	switch f {
+	case MediumFootprint:
+		return []geom.Key{anchor, anchor.ToNE(), anchor.ToSE()}
+	case LargeFootprint:
+		return append([]geom.Key{anchor}, anchor.ToN(), anchor.ToNE(), anchor.ToSE(), anchor.ToS(), anchor.ToSW(), anchor.ToNW())
+	default:
+		return []geom.Key{anchor}
+	}
+}
@@ -1,5 +1,7 @@
 package item
 
+import "fmt"
+
 // Equipment is a Component that stores the equipped items of a Character.
 type Equipment struct {
 	Weapon *Instance
@@ -91,3 +93,35 @@ func (equip *Equipment) WeaponBaseChanceToHit() float64 {
 	}
 	return equip.Weapon.BaseChanceToHit
 }
+
+// Equip puts it into the slot for its Class, and returns the Instance that
+// was displaced from that slot, if any. Rings go in the first free ring slot.
+func (equip *Equipment) Equip(it *Instance) *Instance {
+	var slot **Instance
+	switch {
+	case it.Class.IsWeapon():
+		slot = &equip.Weapon
+	case it.Class == HatClass:
+		slot = &equip.Helm
+	case it.Class == BodyArmorClass:
+		slot = &equip.Armor
+	case it.Class == AmuletClass:
+		slot = &equip.Amulet
+	case it.Class == RingClass:
+		slot = &equip.Ring1
+		if equip.Ring1 != nil && equip.Ring2 == nil {
+			slot = &equip.Ring2
+		}
+	case it.Class == GloveClass:
+		slot = &equip.Gloves
+	case it.Class == BootClass:
+		slot = &equip.Boots
+	case it.Class == BeltClass:
+		slot = &equip.Belt
+	default:
+		panic(fmt.Sprintf("cannot equip item of class %v", it.Class))
+	}
+	displaced := *slot
+	*slot = it
+	return displaced
+}
@@ -0,0 +1,14 @@
+package item
+
+// Inventory is a Component that stores the belongings of a Squad that are not
+// equipped by any of its members.
+type Inventory struct {
+	Gold        int
+	Consumables map[string]int
+	Items       []*Instance
+}
+
+// Type of this Component.
+func (*Inventory) Type() string {
+	return "Inventory"
+}
@@ -1,6 +1,10 @@
 package item
 
-import "github.com/griffithsh/squads/skill"
+import (
+	"fmt"
+
+	"github.com/griffithsh/squads/skill"
+)
 
 //go:generate stringer -type=Class
 
@@ -36,6 +40,17 @@ func (c Class) IsWeapon() bool {
 	return c >= UnarmedClass && c <= WandClass
 }
 
+// UnmarshalText allows Classes to be configured by name in game data.
+func (c *Class) UnmarshalText(text []byte) error {
+	for i := UnarmedClass; i <= BeltClass; i++ {
+		if i.String() == string(text) {
+			*c = i
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown item class %q", text)
+}
+
 // Instance is a rolled item that can be equipped.
 type Instance struct {
 	Class Class
@@ -54,3 +69,15 @@ type Instance struct {
 
 	Skills []skill.ID
 }
+
+// Clone copies an Instance, so that changes to the copy do not affect the
+// original.
+func (it *Instance) Clone() *Instance {
+	result := *it
+	result.Modifiers = make(map[Modifier]float64, len(it.Modifiers))
+	for mod, val := range it.Modifiers {
+		result.Modifiers[mod] = val
+	}
+	result.Skills = append(result.Skills[:0:0], it.Skills...)
+	return &result
+}
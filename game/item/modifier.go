@@ -1,5 +1,7 @@
 package item
 
+import "fmt"
+
 //go:generate stringer -type=Modifier
 
 // Modifier enumerates the stat modifiers that appear on items and effects in
@@ -36,4 +38,29 @@ const (
 	// improves the chance to hit by 10%. A value of -0.5 halves the chance to
 	// hit.
 	ChanceToHitModifier
+
+	// EnergyModifier is added to the Character's maximum Energy.
+	EnergyModifier
+
+	// StrengthModifier, AgilityModifier, IntelligenceModifier and
+	// VitalityModifier are added to the attributes that the Character has
+	// accumulated from levelling.
+	StrengthModifier
+	AgilityModifier
+	IntelligenceModifier
+	VitalityModifier
+
+	// HealthModifier is added to the Character's base health.
+	HealthModifier
 )
+
+// UnmarshalText allows Modifiers to be configured by name in game data.
+func (m *Modifier) UnmarshalText(text []byte) error {
+	for i := BaseMinDamageModifier; i <= HealthModifier; i++ {
+		if i.String() == string(text) {
+			*m = i
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown modifier %q", text)
+}
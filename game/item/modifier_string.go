@@ -15,11 +15,17 @@ func _() {
 	_ = x[PreparationModifier-4]
 	_ = x[ActionPointModifier-5]
 	_ = x[ChanceToHitModifier-6]
+	_ = x[EnergyModifier-7]
+	_ = x[StrengthModifier-8]
+	_ = x[AgilityModifier-9]
+	_ = x[IntelligenceModifier-10]
+	_ = x[VitalityModifier-11]
+	_ = x[HealthModifier-12]
 }
 
-const _Modifier_name = "BaseMinDamageModifierBaseMaxDamageModifierBaseDamageModifierDamageMultiplierModifierPreparationModifierActionPointModifierChanceToHitModifier"
+const _Modifier_name = "BaseMinDamageModifierBaseMaxDamageModifierBaseDamageModifierDamageMultiplierModifierPreparationModifierActionPointModifierChanceToHitModifierEnergyModifierStrengthModifierAgilityModifierIntelligenceModifierVitalityModifierHealthModifier"
 
-var _Modifier_index = [...]uint8{0, 21, 42, 60, 84, 103, 122, 141}
+var _Modifier_index = [...]uint8{0, 21, 42, 60, 84, 103, 122, 141, 155, 171, 186, 206, 222, 236}
 
 func (i Modifier) String() string {
 	if i < 0 || i >= Modifier(len(_Modifier_index)-1) {
@@ -12,6 +12,9 @@ type Leash struct {
 	Owner ecs.Entity
 
 	LayerOffset int
+
+	// X and Y offset the Position of the Entity from its Owner.
+	X, Y float64
 }
 
 // Type of this Component.
@@ -35,6 +38,8 @@ func (sys *LeashSystem) Update(mgr *ecs.World, elapsed time.Duration) {
 
 		newPos := *pos
 		newPos.Layer += leash.LayerOffset
+		newPos.Center.X += leash.X
+		newPos.Center.Y += leash.Y
 		mgr.AddComponent(e, &newPos)
 	}
 }
@@ -1,6 +1,10 @@
 package game
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/griffithsh/squads/geom"
+)
 
 // ObstacleType is an enum.
 type ObstacleType int
@@ -29,11 +33,29 @@ const (
 	MudObstacle
 )
 
-// Obstacle is a Component that blocks a Hex.
+// Obstacle is a Component that blocks a Hex, or several Hexes when its
+// Footprint is larger than a single Hex. M and N are the anchor of the
+// Footprint.
 type Obstacle struct {
 	M, N int
 
 	ObstacleType ObstacleType
+	Footprint    Footprint
+}
+
+// Keys returns every Key that the Obstacle blocks.
+func (o *Obstacle) Keys() []geom.Key {
+	return o.Footprint.Keys(geom.Key{M: o.M, N: o.N})
+}
+
+// Occupies determines whether the Obstacle blocks the Key k.
+func (o *Obstacle) Occupies(k geom.Key) bool {
+	for _, key := range o.Keys() {
+		if key == k {
+			return true
+		}
+	}
+	return false
 }
 
 // Type of the Component.
@@ -3,6 +3,7 @@ package overworld
 import (
 	"github.com/griffithsh/squads/ecs"
 	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game/overworld/procedural"
 	"github.com/griffithsh/squads/geom"
 )
 
@@ -38,7 +39,12 @@ func (Complete) Type() event.Type {
 // CombatInitiated occurs when the player has met another squad for combat.
 type CombatInitiated struct {
 	Squads []ecs.Entity
-	// info about the terrain?
+
+	// Terrain is the overworld terrain where the squads met.
+	Terrain procedural.Code
+
+	// Seed for generating the combat map.
+	Seed int64
 }
 
 // Type of the Event.
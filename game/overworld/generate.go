@@ -5,13 +5,13 @@ import (
 
 	"github.com/griffithsh/squads/baddy"
 	"github.com/griffithsh/squads/game/overworld/procedural"
+	"github.com/griffithsh/squads/game/stats"
 	"github.com/griffithsh/squads/squad"
 
-	"github.com/griffithsh/squads/game"
 	"github.com/griffithsh/squads/geom"
 )
 
-func generateProcedural(rng *rand.Rand, recipe *procedural.Generator, lvl int) Map {
+func generateProcedural(rng *rand.Rand, recipe *procedural.Generator, lvl int, archive Archive) Map {
 	generated := recipe.Generate(rng.Int63(), lvl)
 
 	nodes := map[geom.Key]*Node{}
@@ -27,18 +27,19 @@ func generateProcedural(rng *rand.Rand, recipe *procedural.Generator, lvl int) M
 		}
 	}
 
-	enemies := map[geom.Key][]*game.Character{}
+	model := archive.Difficulty()
+	enemies := map[geom.Key][]Opponent{}
 	for key, recipeID := range generated.Opponents {
-		characters := []*game.Character{}
-		for _, recipeID := range squad.Recipes[recipeID].Construct(rng) {
+		opponents := []Opponent{}
+		for _, recipeID := range model.Squad(rng, squad.Recipes[recipeID], lvl) {
 			char := baddy.Recipes[recipeID].Construct(rng)
-			char.Level = lvl
-			vit := int(char.VitalityPerLevel * float64(char.Level))
-			hp := char.BaseHealth
-			char.CurrentHealth = game.MaxHealth(hp, vit)
-			characters = append(characters, char)
+			char.Baddy = recipeID.String()
+			equip := model.Scale(char, lvl)
+			prof := archive.Profession(char.Profession)
+			char.CurrentHealth = stats.Derive(char, prof, equip).MaxHealth
+			opponents = append(opponents, Opponent{Character: char, Equipment: equip})
 		}
-		enemies[key] = characters
+		enemies[key] = opponents
 	}
 
 	d := Map{
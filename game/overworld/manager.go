@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/griffithsh/squads/difficulty"
 	"github.com/griffithsh/squads/ecs"
 	"github.com/griffithsh/squads/event"
 	"github.com/griffithsh/squads/game"
@@ -21,6 +22,8 @@ type Archive interface {
 	GetRecipes() []*procedural.Generator
 	GetAnimation(name string) game.FrameAnimation
 	GetOverworldBaseTiles() map[procedural.Code]hbg.BaseTile
+	Profession(profession string) *game.ProfessionDetails
+	Difficulty() difficulty.Model
 }
 
 // Manager is a game state that allows the player to pick which path to take,
@@ -38,7 +41,21 @@ type Manager struct {
 
 	fogged map[geom.Key]ecs.Entity
 
+	// terrain of the current overworld, which is where combats are fought.
+	terrain map[geom.Key]procedural.Code
+
+	// nodes of the current overworld.
+	nodes map[geom.Key]*Node
+
+	// retreat is the node the player's squad came from, where it falls back
+	// to when it escapes from a combat.
+	retreat geom.Key
+
 	rng *rand.Rand
+
+	// announcements are presented over the player's squad when the overworld
+	// is next enabled.
+	announcements []string
 }
 
 // NewManager creates a new overworld Manager.
@@ -57,10 +74,61 @@ func NewManager(mgr *ecs.World, bus *event.Bus, archive Archive) *Manager {
 
 	bus.Subscribe(TokensCollided{}.Type(), m.handleTokensCollided)
 	bus.Subscribe(game.WindowSizeChanged{}.Type(), m.handleWindowSizeChanged)
+	bus.Subscribe(game.CharacterLevelledUp{}.Type(), m.handleCharacterLevelledUp)
+	bus.Subscribe(game.MasteryImproved{}.Type(), m.handleMasteryImproved)
+	bus.Subscribe(game.CombatConcluded{}.Type(), m.handleCombatConcluded)
 
 	return &m
 }
 
+func (m *Manager) handleCharacterLevelledUp(t event.Typer) {
+	ev := t.(*game.CharacterLevelledUp)
+	char := m.mgr.Component(ev.Character, "Character").(*game.Character)
+	m.announcements = append(m.announcements, fmt.Sprintf("%s reached level %d", char.Name, ev.Level))
+}
+
+func (m *Manager) handleMasteryImproved(t event.Typer) {
+	ev := t.(*game.MasteryImproved)
+	char := m.mgr.Component(ev.Character, "Character").(*game.Character)
+	m.announcements = append(m.announcements, fmt.Sprintf("%s: %v %d", char.Name, ev.Mastery, ev.Level))
+}
+
+// announce any pending announcements over the player's squad token.
+func (m *Manager) announce() {
+	var position *game.Position
+	for _, e := range m.mgr.Tagged("player") {
+		if p, ok := m.mgr.Component(e, "Position").(*game.Position); ok && m.mgr.Component(e, "Token") != nil {
+			position = p
+			break
+		}
+	}
+	if position == nil {
+		return
+	}
+
+	for i, text := range m.announcements {
+		e := m.mgr.NewEntity()
+		m.mgr.Tag(e, "overworld")
+		m.mgr.AddComponent(e, &game.Font{
+			Text: text,
+		})
+		m.mgr.AddComponent(e, &game.Position{
+			Center: game.Center{
+				X: position.Center.X - float64(len(text)*5)/2,
+				Y: position.Center.Y - 40 - float64(i*10),
+			},
+			Layer: position.Layer + 1,
+		})
+		m.mgr.AddComponent(e, &game.FloatAwayAnimation{
+			Rate: 6.5,
+		})
+		m.mgr.AddComponent(e, &ecs.Expiry{
+			Remaining: time.Millisecond * 3000,
+		})
+	}
+	m.announcements = m.announcements[:0]
+}
+
 func (m *Manager) handleSquadTokensCollided(e1, e2 ecs.Entity) {
 	m.setState(FadingOut)
 	m.mgr.AddComponent(m.mgr.NewEntity(), &game.DiagonalMatrixWipe{
@@ -74,14 +142,41 @@ func (m *Manager) handleSquadTokensCollided(e1, e2 ecs.Entity) {
 					squads = append(squads, token.Presence)
 				}
 			}
+			token := m.mgr.Component(e1, "Token").(*Token)
 			m.bus.Publish(&CombatInitiated{
-				Squads: squads,
-				// TODO: info about terrain
+				Squads:  squads,
+				Terrain: m.terrain[token.Key],
+				Seed:    m.rng.Int63(),
 			})
 		},
 	})
 }
 
+// handleCombatConcluded moves the player's squad back to the node it came
+// from when it has escaped, so that it is no longer on the same node as the
+// squad it escaped from.
+func (m *Manager) handleCombatConcluded(t event.Typer) {
+	ev := t.(*game.CombatConcluded)
+	for _, e := range m.mgr.Tagged("player") {
+		token, ok := m.mgr.Component(e, "Token").(*Token)
+		if !ok || ev.Results[token.Presence] != game.Escaped {
+			continue
+		}
+		n, ok := m.nodes[m.retreat]
+		if !ok || token.Key == m.retreat {
+			continue
+		}
+		token.Key = m.retreat
+		node := m.mgr.Component(n.e, "Position").(*game.Position)
+		position := m.mgr.Component(e, "Position").(*game.Position)
+		position.Center = node.Center
+		m.bus.Publish(&game.SomethingInteresting{
+			X: node.Center.X,
+			Y: node.Center.Y,
+		})
+	}
+}
+
 func (m *Manager) handleExitGateCollided() {
 	m.setState(FadingOut)
 	m.mgr.AddComponent(m.mgr.NewEntity(), &game.DiagonalMatrixWipe{
@@ -171,6 +266,7 @@ func (m *Manager) newNodeClickHandler(n *Node) func(x, y float64) {
 				Duration:    800 * time.Millisecond,
 				Destination: refPos.Center,
 				Complete: func() {
+					m.retreat = t.Key
 					m.bus.Publish(&TokenMoved{
 						E:    e,
 						From: t.Key,
@@ -243,6 +339,9 @@ func (m *Manager) playerTeam() *game.Team {
 }
 
 func (m *Manager) boot(d Map) {
+	m.terrain = d.Terrain
+	m.nodes = d.Nodes
+	m.retreat = d.Start
 	f := geom.NewField(66, 31, 64)
 	// Add a Sprite for every Node.
 	positions := map[geom.Key]game.Center{}
@@ -398,11 +497,14 @@ func (m *Manager) boot(d Map) {
 		})
 
 		squad := m.mgr.Component(e, "Squad").(*game.Squad)
-		for _, character := range squadMembers {
+		for _, opponent := range squadMembers {
 			e = m.mgr.NewEntity()
 			m.mgr.Tag(e, "overworld")
 			m.mgr.Tag(e, "baddy")
-			m.mgr.AddComponent(e, character)
+			m.mgr.AddComponent(e, opponent.Character)
+			if opponent.Equipment != nil {
+				m.mgr.AddComponent(e, opponent.Equipment)
+			}
 			m.mgr.AddComponent(e, enemyTeam)
 			squad.Members = append(squad.Members, e)
 		}
@@ -542,7 +644,7 @@ func (m *Manager) handleCardSelected(e ecs.Entity, others []ecs.Entity, recipe *
 					},
 					OnInitialised: func() {
 						// and boot from the recipe.
-						d := generateProcedural(m.rng, recipe, lvl)
+						d := generateProcedural(m.rng, recipe, lvl, m.archive)
 						m.boot(d)
 					},
 				})
@@ -707,6 +809,7 @@ func (m *Manager) Enable() {
 				for _, e := range m.mgr.Tagged("overworld") {
 					m.mgr.RemoveComponent(e, &game.Hidden{})
 				}
+				m.announce()
 			},
 		})
 		m.dormant = false
@@ -5,6 +5,7 @@ import (
 
 	"github.com/griffithsh/squads/ecs"
 	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/item"
 	"github.com/griffithsh/squads/game/overworld/procedural"
 	"github.com/griffithsh/squads/geom"
 )
@@ -20,6 +21,14 @@ type Node struct {
 	Connected map[geom.DirectionType]geom.Key
 }
 
+// Opponent is a member of an enemy squad.
+type Opponent struct {
+	Character *game.Character
+
+	// Equipment is nil when the Opponent carries nothing.
+	Equipment *item.Equipment
+}
+
 // Map of an overworld.
 type Map struct {
 	// Terrain stores the visible tiles of an overworld.
@@ -29,8 +38,8 @@ type Map struct {
 	// between them.
 	Nodes map[geom.Key]*Node
 
-	// Enemies stores rolled enemy squad locations and their types.
-	Enemies map[geom.Key][]*game.Character
+	// Enemies stores rolled enemy squad locations and their members.
+	Enemies map[geom.Key][]Opponent
 
 	// Start stores the rolled location for where the player should start in
 	// this overworld map.
@@ -3,4 +3,19 @@ package game
 type ProfessionDetails struct {
 	ActionPoints int
 	Preparation  int
+
+	// Strength, Agility, Intelligence and Vitality are granted to every
+	// Character of the profession, regardless of their level.
+	Strength     int
+	Agility      int
+	Intelligence int
+	Vitality     int
+
+	// Footprint is how many hexes Characters of the profession occupy in
+	// combat.
+	Footprint Footprint
+
+	// Traversal overrides how long it takes Characters of the profession to
+	// cross terrain and obstacles in combat.
+	Traversal Traversal
 }
@@ -0,0 +1,12 @@
+package rewards
+
+import "github.com/griffithsh/squads/event"
+
+// Claimed occurs when the player has finished assigning the rewards of a
+// combat.
+type Claimed struct{}
+
+// Type of the Event.
+func (Claimed) Type() event.Type {
+	return "rewards.Claimed"
+}
@@ -0,0 +1,147 @@
+package rewards
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/event"
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/item"
+	"github.com/griffithsh/squads/loot"
+	"github.com/griffithsh/squads/ui"
+)
+
+// Manager presents the loot won in a combat, and lets the player give the
+// Items to the members of their Squad before carrying on.
+type Manager struct {
+	mgr *ecs.World
+	bus *event.Bus
+
+	squad ecs.Entity
+	haul  loot.Haul
+	uic   *ui.UI
+}
+
+// NewManager constructs a new rewards Manager.
+func NewManager(mgr *ecs.World, bus *event.Bus) *Manager {
+	return &Manager{
+		mgr: mgr,
+		bus: bus,
+	}
+}
+
+// Begin presenting haul to the Squad squad. The Gold and Consumables are
+// stashed in the Inventory of the Squad straight away.
+func (m *Manager) Begin(squad ecs.Entity, haul loot.Haul) {
+	m.squad = squad
+	m.haul = haul
+	haul.Stash(m.inventory())
+
+	f, err := os.Open("game/rewards/rewards.xml")
+	if err != nil {
+		panic(fmt.Sprintf("%v", err))
+	}
+	m.uic = ui.NewUI(f)
+	m.refresh()
+
+	e := m.mgr.NewEntity()
+	m.mgr.Tag(e, "rewards")
+	m.mgr.AddComponent(e, m.uic)
+}
+
+// End the presentation of rewards.
+func (m *Manager) End() {
+	for _, e := range m.mgr.Tagged("rewards") {
+		m.mgr.DestroyEntity(e)
+	}
+	m.uic = nil
+	m.haul = loot.Haul{}
+}
+
+// inventory of the Squad, which is added if the Squad does not have one yet.
+func (m *Manager) inventory() *item.Inventory {
+	inv, ok := m.mgr.Component(m.squad, "Inventory").(*item.Inventory)
+	if !ok {
+		inv = &item.Inventory{Consumables: map[string]int{}}
+		m.mgr.AddComponent(m.squad, inv)
+	}
+	return inv
+}
+
+// give the Item at index i of the haul to member, putting anything it
+// displaces in the Inventory of the Squad.
+func (m *Manager) give(i int, member ecs.Entity) {
+	equip, ok := m.mgr.Component(member, "Equipment").(*item.Equipment)
+	if !ok {
+		equip = &item.Equipment{}
+		m.mgr.AddComponent(member, equip)
+	}
+	if displaced := equip.Equip(m.haul.Items[i]); displaced != nil {
+		inv := m.inventory()
+		inv.Items = append(inv.Items, displaced)
+	}
+	m.haul.Items = append(m.haul.Items[:i], m.haul.Items[i+1:]...)
+	m.refresh()
+}
+
+// claim the rewards, keeping any Items that nobody was given.
+func (m *Manager) claim() {
+	inv := m.inventory()
+	inv.Items = append(inv.Items, m.haul.Items...)
+	m.haul.Items = nil
+	m.bus.Publish(&Claimed{})
+}
+
+// refresh the Data of the UI from the haul.
+func (m *Manager) refresh() {
+	type consumable struct {
+		Code  string
+		Count int
+	}
+	type member struct {
+		Label, ID string
+		Handle    func(string)
+	}
+	type reward struct {
+		Name    string
+		Members []member
+	}
+
+	consumables := []consumable{}
+	for code, count := range m.haul.Consumables {
+		consumables = append(consumables, consumable{code, count})
+	}
+	sort.Slice(consumables, func(i, j int) bool {
+		return consumables[i].Code < consumables[j].Code
+	})
+
+	squad := m.mgr.Component(m.squad, "Squad").(*game.Squad)
+	items := []reward{}
+	for i, it := range m.haul.Items {
+		r := reward{Name: it.Name}
+		for _, e := range squad.Members {
+			char := m.mgr.Component(e, "Character").(*game.Character)
+			i, e := i, e
+			r.Members = append(r.Members, member{
+				Label:  char.Name,
+				ID:     fmt.Sprintf("rewards-give-%d-%d", i, e),
+				Handle: func(string) { m.give(i, e) },
+			})
+		}
+		items = append(items, r)
+	}
+
+	m.uic.Data = struct {
+		Gold           int
+		Consumables    []consumable
+		Items          []reward
+		HandleContinue func(string)
+	}{
+		Gold:           m.haul.Gold,
+		Consumables:    consumables,
+		Items:          items,
+		HandleContinue: func(string) { m.claim() },
+	}
+}
@@ -1,10 +1,18 @@
 package game
 
-import "github.com/griffithsh/squads/ecs"
+import (
+	"github.com/griffithsh/squads/ecs"
+	"github.com/griffithsh/squads/geom"
+)
 
 // Squad is a Component that marks a Squad of Characters
 type Squad struct {
 	Members []ecs.Entity
+
+	// Formation is an optional arrangement of Members to deploy at the start
+	// of a combat. It stores the offset of each Member from the start of the
+	// Squad's team.
+	Formation map[ecs.Entity]geom.Cube
 }
 
 // Type of this Component.
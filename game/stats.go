@@ -1,9 +1,18 @@
 package game
 
+// MaxHealth derives the maximum health of a Character from their raw health and
+// vitality. Both should already include any bonuses from equipped items.
 func MaxHealth(rawHealth, vitality int) int {
 	const healthPerVit float64 = 9.5
-	// TODO: Also include vitality and base health from equipped items.
 
 	return rawHealth + int(healthPerVit*float64(vitality)) + 25
 
 }
+
+// MaxEnergy derives the size of a Character's energy pool from their
+// intelligence and any energy granted by their equipment.
+func MaxEnergy(intelligence int, equipped float64) int {
+	const energyPerInt float64 = 4.5
+
+	return int(energyPerInt*float64(intelligence)+equipped) + 20
+}
@@ -0,0 +1,44 @@
+// Package stats derives the final attributes of a Character from their level,
+// profession and equipment.
+package stats
+
+import (
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/item"
+)
+
+// Attributes are the final values of a Character's stats, after their level,
+// profession and equipment have been taken into account.
+type Attributes struct {
+	Strength     int
+	Agility      int
+	Intelligence int
+	Vitality     int
+
+	Preparation  int
+	ActionPoints int
+
+	MaxHealth int
+	MaxEnergy int
+}
+
+// Derive the Attributes of a Character. Equipment may be nil when the
+// Character has nothing equipped.
+func Derive(char *game.Character, prof *game.ProfessionDetails, equip *item.Equipment) Attributes {
+	mods := equip.SumModifiers()
+	lvl := float64(char.Level)
+
+	attrs := Attributes{
+		Strength:     int(char.StrengthPerLevel*lvl+mods[item.StrengthModifier]) + prof.Strength,
+		Agility:      int(char.AgilityPerLevel*lvl+mods[item.AgilityModifier]) + prof.Agility,
+		Intelligence: int(char.IntelligencePerLevel*lvl+mods[item.IntelligenceModifier]) + prof.Intelligence,
+		Vitality:     int(char.VitalityPerLevel*lvl+mods[item.VitalityModifier]) + prof.Vitality,
+
+		Preparation:  char.InherantPreparation + prof.Preparation + equip.WeaponPreparation(),
+		ActionPoints: char.InherantActionPoints + prof.ActionPoints + equip.WeaponActionPoints(),
+	}
+	attrs.MaxHealth = game.MaxHealth(char.BaseHealth+int(mods[item.HealthModifier]), attrs.Vitality)
+	attrs.MaxEnergy = game.MaxEnergy(attrs.Intelligence, mods[item.EnergyModifier])
+
+	return attrs
+}
@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/griffithsh/squads/game"
+	"github.com/griffithsh/squads/game/item"
+)
+
+func TestDerive(t *testing.T) {
+	char := game.Character{
+		Level:                4,
+		StrengthPerLevel:     2.5,
+		AgilityPerLevel:      1.25,
+		IntelligencePerLevel: 1,
+		VitalityPerLevel:     2,
+		BaseHealth:           25,
+		InherantPreparation:  10,
+		InherantActionPoints: 5,
+	}
+	prof := game.ProfessionDetails{
+		ActionPoints: 60,
+		Preparation:  200,
+		Strength:     3,
+	}
+	sword := &item.Equipment{
+		Weapon: &item.Instance{
+			Class: item.SwordClass,
+			Modifiers: map[item.Modifier]float64{
+				item.PreparationModifier: 500,
+				item.ActionPointModifier: 20,
+			},
+		},
+		Ring1: &item.Instance{
+			Modifiers: map[item.Modifier]float64{
+				item.VitalityModifier: 2,
+				item.HealthModifier:   10,
+				item.EnergyModifier:   5,
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name  string
+		char  game.Character
+		equip *item.Equipment
+		want  Attributes
+	}{
+		{
+			name:  "unarmed",
+			char:  char,
+			equip: nil,
+			want: Attributes{
+				Strength:     13,
+				Agility:      5,
+				Intelligence: 4,
+				Vitality:     8,
+				Preparation:  610,
+				ActionPoints: 105,
+				MaxHealth:    126,
+				MaxEnergy:    38,
+			},
+		},
+		{
+			name:  "equipped",
+			char:  char,
+			equip: sword,
+			want: Attributes{
+				Strength:     13,
+				Agility:      5,
+				Intelligence: 4,
+				Vitality:     10,
+				Preparation:  710,
+				ActionPoints: 85,
+				MaxHealth:    155,
+				MaxEnergy:    43,
+			},
+		},
+		{
+			name: "level zero",
+			char: func() game.Character {
+				c := char
+				c.Level = 0
+				return c
+			}(),
+			equip: nil,
+			want: Attributes{
+				Strength:     3,
+				Preparation:  610,
+				ActionPoints: 105,
+				MaxHealth:    50,
+				MaxEnergy:    20,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Derive(&tc.char, &prof, tc.equip)
+			if got != tc.want {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package game
+
+// TerrainType describes the ground of a hex in combat.
+type TerrainType int
+
+//go:generate go run github.com/dmarkham/enumer -type=TerrainType -json
+
+// TerrainTypes are the kinds of ground that a combat map hex can have.
+const (
+	OpenTerrain TerrainType = iota
+	ForestTerrain
+	MudTerrain
+	SwampTerrain
+	ShallowWaterTerrain
+	RockTerrain
+)
+
+// defaultTerrainCosts are how many times longer than open ground it takes to
+// cross each TerrainType, when a combat map does not say otherwise.
+var defaultTerrainCosts = map[TerrainType]float64{
+	OpenTerrain:         1.0,
+	ForestTerrain:       1.5,
+	MudTerrain:          2.0,
+	SwampTerrain:        2.0,
+	ShallowWaterTerrain: 2.0,
+	RockTerrain:         1.5,
+}
+
+// HazardType describes something that harms Characters entering a hex.
+type HazardType int
+
+//go:generate go run github.com/dmarkham/enumer -type=HazardType -json
+
+// HazardTypes that a combat map hex can have.
+const (
+	NoHazard HazardType = iota
+	FireHazard
+	PoisonHazard
+	SpikesHazard
+)
+
+// Hazard deals Damage to anything that enters the hex it is on.
+type Hazard struct {
+	Type   HazardType
+	Damage int
+}
+
+// Terrain is a Component that describes the ground of a hex in combat.
+type Terrain struct {
+	M, N int
+
+	TerrainType TerrainType
+
+	// Cost multiplies the time it takes to cross the hex. Zero means the
+	// default Cost of the TerrainType.
+	Cost float64
+
+	// Elevation of the hex. Climbing to a higher hex is slower than walking
+	// on the level.
+	Elevation int
+
+	// Cover is the fraction that the chance to be hit by attacks is reduced
+	// by while standing on the hex.
+	Cover float64
+
+	Hazard Hazard
+
+	// Escape hexes let Participants escape the combat from next to them, as
+	// well as from the edge of the field.
+	Escape bool
+}
+
+// Type of the Component.
+func (*Terrain) Type() string {
+	return "Terrain"
+}
+
+// Multiplier returns how many times longer than open ground it takes to cross
+// the hex for a Character with the given Traversal.
+func (t *Terrain) Multiplier(traversal Traversal) float64 {
+	if cost, ok := traversal.Terrain[t.TerrainType]; ok {
+		return cost
+	}
+	if t.Cost != 0 {
+		return t.Cost
+	}
+	if cost, ok := defaultTerrainCosts[t.TerrainType]; ok {
+		return cost
+	}
+	return 1.0
+}
+
+// Traversal overrides the usual costs of crossing terrain and obstacles for a
+// profession. A bird can fly right over a tree, and a snake is not impeded by a
+// swamp.
+type Traversal struct {
+	Terrain   map[TerrainType]float64
+	Obstacles map[ObstacleType]float64
+}
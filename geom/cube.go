@@ -0,0 +1,143 @@
+package geom
+
+// Cube is a coordinate for a hex in a system with three axes, where X+Y+Z is
+// always zero. Unlike the offset coordinates of a Key, Cubes need no special
+// cases for odd and even columns, so they suit arithmetic like distances,
+// rotations and lines. Convert to and from Keys with Key.Cube and Cube.Key.
+type Cube struct {
+	X, Y, Z int
+}
+
+// Cube converts a Key to its Cube coordinate.
+func (k Key) Cube() Cube {
+	x := k.M
+	z := k.N - (k.M-(k.M&1))/2
+	return Cube{x, -x - z, z}
+}
+
+// Key converts a Cube to its Key.
+func (c Cube) Key() Key {
+	return Key{M: c.X, N: c.Z + (c.X-(c.X&1))/2}
+}
+
+// cubeDirections are the offsets to the adjacent Cube in each DirectionType.
+var cubeDirections = map[DirectionType]Cube{
+	N:  {0, 1, -1},
+	NE: {1, 0, -1},
+	SE: {1, -1, 0},
+	S:  {0, -1, 1},
+	SW: {-1, 0, 1},
+	NW: {-1, 1, 0},
+}
+
+// CubeDirection returns the offset of the Cube that is adjacent in the
+// direction dir.
+func CubeDirection(dir DirectionType) Cube {
+	return cubeDirections[dir]
+}
+
+// Add two Cubes together.
+func (c Cube) Add(o Cube) Cube {
+	return Cube{c.X + o.X, c.Y + o.Y, c.Z + o.Z}
+}
+
+// Sub subtracts o from c.
+func (c Cube) Sub(o Cube) Cube {
+	return Cube{c.X - o.X, c.Y - o.Y, c.Z - o.Z}
+}
+
+// Scale multiplies each axis of c by n.
+func (c Cube) Scale(n int) Cube {
+	return Cube{c.X * n, c.Y * n, c.Z * n}
+}
+
+// Neighbor returns the Cube that is adjacent to c in the direction dir.
+func (c Cube) Neighbor(dir DirectionType) Cube {
+	return c.Add(cubeDirections[dir])
+}
+
+func absInt(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// Distance returns how many hexes away o is from c.
+func (c Cube) Distance(o Cube) int {
+	d := c.Sub(o)
+	return max(absInt(d.X), absInt(d.Y), absInt(d.Z))
+}
+
+// RotateAbout rotates c around pivot by steps sixths of a turn. Positive
+// steps rotate clockwise, and negative steps rotate anticlockwise.
+func (c Cube) RotateAbout(pivot Cube, steps int) Cube {
+	d := c.Sub(pivot)
+	steps = ((steps % 6) + 6) % 6
+	for i := 0; i < steps; i++ {
+		d = Cube{-d.Z, -d.X, -d.Y}
+	}
+	return pivot.Add(d)
+}
+
+// ReflectAbout mirrors c across the line that passes through pivot in the
+// direction dir. Reflecting across N and S, for example, swaps east and
+// west.
+func (c Cube) ReflectAbout(pivot Cube, dir DirectionType) Cube {
+	d := c.Sub(pivot)
+	switch dir {
+	case N, S:
+		d = Cube{-d.X, -d.Z, -d.Y}
+	case NE, SW:
+		d = Cube{-d.Z, -d.Y, -d.X}
+	case SE, NW:
+		d = Cube{-d.Y, -d.X, -d.Z}
+	}
+	return pivot.Add(d)
+}
+
+// Ring returns the Cubes that are radius hexes from center. They are in
+// clockwise order, beginning with the Cube that is radius hexes N of center.
+func Ring(center Cube, radius int) []Cube {
+	if radius <= 0 {
+		return []Cube{center}
+	}
+	result := make([]Cube, 0, 6*radius)
+	c := center.Add(cubeDirections[N].Scale(radius))
+	for side := 0; side < 6; side++ {
+		// From the N corner, the first side of the ring heads SE, and each
+		// following side turns clockwise again.
+		step := cubeDirections[DirectionType((side+2)%6)]
+		for i := 0; i < radius; i++ {
+			result = append(result, c)
+			c = c.Add(step)
+		}
+	}
+	return result
+}
+
+// Spiral returns the Cubes that are within radius hexes of center, beginning
+// with center and then each Ring outward in turn.
+func Spiral(center Cube, radius int) []Cube {
+	result := []Cube{center}
+	for r := 1; r <= radius; r++ {
+		result = append(result, Ring(center, r)...)
+	}
+	return result
+}
+
+// Intersect returns the Cubes that are both within ra hexes of a and within rb
+// hexes of b.
+func Intersect(a Cube, ra int, b Cube, rb int) []Cube {
+	xmin, xmax := max(a.X-ra, b.X-rb), min(a.X+ra, b.X+rb)
+	ymin, ymax := max(a.Y-ra, b.Y-rb), min(a.Y+ra, b.Y+rb)
+	zmin, zmax := max(a.Z-ra, b.Z-rb), min(a.Z+ra, b.Z+rb)
+
+	result := []Cube{}
+	for x := xmin; x <= xmax; x++ {
+		for y := max(ymin, -x-zmax); y <= min(ymax, -x-zmin); y++ {
+			result = append(result, Cube{x, y, -x - y})
+		}
+	}
+	return result
+}
@@ -0,0 +1,213 @@
+package geom
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"testing/quick"
+)
+
+// smallKey is a Key near the origin, so that randomly generated pairs of Keys
+// are sometimes close together.
+type smallKey Key
+
+func (smallKey) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(smallKey{M: r.Intn(41) - 20, N: r.Intn(41) - 20})
+}
+
+var quickConfig = &quick.Config{MaxCount: 2000}
+
+func sortKeys(keys []Key) []Key {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].M != keys[j].M {
+			return keys[i].M < keys[j].M
+		}
+		return keys[i].N < keys[j].N
+	})
+	return keys
+}
+
+func TestCubeRoundTrip(t *testing.T) {
+	f := func(k smallKey) bool {
+		c := Key(k).Cube()
+		return c.X+c.Y+c.Z == 0 && c.Key() == Key(k)
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCubeDistance(t *testing.T) {
+	// HexesFrom works in offset coordinates, so it checks the cube maths
+	// independently.
+	f := func(a, b smallKey) bool {
+		return Key(a).Cube().Distance(Key(b).Cube()) == Key(a).HexesFrom(Key(b))
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCubeNeighbor(t *testing.T) {
+	f := func(k smallKey) bool {
+		for _, dir := range DirectionTypeValues() {
+			if Key(k).Cube().Neighbor(dir).Key() != Key(k).ToDirection(dir) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRing(t *testing.T) {
+	f := func(k smallKey, r uint8) bool {
+		radius := int(r%6) + 1
+		center := Key(k).Cube()
+		ring := Ring(center, radius)
+		if len(ring) != 6*radius {
+			return false
+		}
+		if ring[0] != center.Add(CubeDirection(N).Scale(radius)) {
+			return false
+		}
+		for i, c := range ring {
+			if Key(k).HexesFrom(c.Key()) != radius {
+				return false
+			}
+			if c.Distance(ring[(i+1)%len(ring)]) != 1 {
+				return false
+			}
+		}
+		// The Ring agrees with the Keys found by the search of ExpandBy.
+		want := sortKeys(Key(k).ExpandBy(radius, radius))
+		got := sortKeys(toKeys(ring))
+		return reflect.DeepEqual(want, got)
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+
+	// Rings proceed clockwise.
+	if got, want := Ring(Cube{}, 1)[1], CubeDirection(SE).Add(CubeDirection(N)); got != want {
+		t.Errorf("want second hex of ring %v, got %v", want, got)
+	}
+}
+
+func TestSpiral(t *testing.T) {
+	f := func(k smallKey, r uint8) bool {
+		radius := int(r % 6)
+		spiral := Spiral(Key(k).Cube(), radius)
+		if len(spiral) != 1+3*radius*(radius+1) {
+			return false
+		}
+		for i := 1; i < len(spiral); i++ {
+			// Each step of the spiral is no closer to the center.
+			if spiral[i].Distance(spiral[0]) < spiral[i-1].Distance(spiral[0]) {
+				return false
+			}
+		}
+		want := sortKeys(Key(k).ExpandBy(0, radius))
+		got := sortKeys(toKeys(spiral))
+		return reflect.DeepEqual(want, got)
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRotateAbout(t *testing.T) {
+	f := func(k, p smallKey, steps int8) bool {
+		c, pivot := Key(k).Cube(), Key(p).Cube()
+		rotated := c.RotateAbout(pivot, int(steps))
+
+		// Rotation preserves distance from the pivot and keeps the cube
+		// coordinate valid.
+		if rotated.X+rotated.Y+rotated.Z != 0 || rotated.Distance(pivot) != c.Distance(pivot) {
+			return false
+		}
+		// Six sixths is a whole turn, and opposite rotations cancel out.
+		if c.RotateAbout(pivot, 6) != c || rotated.RotateAbout(pivot, -int(steps)) != c {
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+
+	// One step clockwise turns each direction into the next.
+	for _, dir := range DirectionTypeValues() {
+		next := DirectionType((int(dir) + 1) % 6)
+		if got := CubeDirection(dir).RotateAbout(Cube{}, 1); got != CubeDirection(next) {
+			t.Errorf("want %v rotated to %v, got %v", dir, next, got)
+		}
+	}
+}
+
+func TestReflectAbout(t *testing.T) {
+	f := func(k, p smallKey, d uint8) bool {
+		c, pivot := Key(k).Cube(), Key(p).Cube()
+		dir := DirectionType(d % 6)
+		reflected := c.ReflectAbout(pivot, dir)
+		if reflected.X+reflected.Y+reflected.Z != 0 || reflected.Distance(pivot) != c.Distance(pivot) {
+			return false
+		}
+		// Reflecting twice is the identity.
+		if reflected.ReflectAbout(pivot, dir) != c {
+			return false
+		}
+		// Hexes on the axis are unmoved.
+		onAxis := pivot.Add(CubeDirection(dir).Scale(int(d % 5)))
+		return onAxis.ReflectAbout(pivot, dir) == onAxis
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+
+	// Reflecting across N and S swaps east and west.
+	for dir, want := range map[DirectionType]DirectionType{N: N, NE: NW, SE: SW, S: S, SW: SE, NW: NE} {
+		if got := CubeDirection(dir).ReflectAbout(Cube{}, N); got != CubeDirection(want) {
+			t.Errorf("want %v reflected to %v, got %v", dir, want, got)
+		}
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	f := func(a, b smallKey, ra, rb uint8) bool {
+		rangeA, rangeB := int(ra%8), int(rb%8)
+		want := []Key{}
+		for _, k := range Key(a).ExpandBy(0, rangeA) {
+			if k.HexesFrom(Key(b)) <= rangeB {
+				want = append(want, k)
+			}
+		}
+		got := toKeys(Intersect(Key(a).Cube(), rangeA, Key(b).Cube(), rangeB))
+		return reflect.DeepEqual(sortKeys(want), sortKeys(got))
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCubeLine(t *testing.T) {
+	f := func(a, b smallKey) bool {
+		ca, cb := Key(a).Cube(), Key(b).Cube()
+		l := CubeLine(ca, cb)
+		if len(l) != Key(a).HexesFrom(Key(b))+1 || l[0] != ca || l[len(l)-1] != cb {
+			return false
+		}
+		for i := 1; i < len(l); i++ {
+			if l[i-1].Distance(l[i]) != 1 {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
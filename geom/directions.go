@@ -129,3 +129,15 @@ func Actualize(facing DirectionType, relative RelativeDirection) DirectionType {
 		return facing
 	}
 }
+
+// Relativize is the inverse of Actualize. It finds the RelativeDirection that
+// actual is from the perspective of something facing in the facing direction.
+// S facing and N actual make Behind. S facing and S actual make Forward etc.
+func Relativize(facing, actual DirectionType) RelativeDirection {
+	for _, relative := range RelativeDirectionValues() {
+		if Actualize(facing, relative) == actual {
+			return relative
+		}
+	}
+	return Forward
+}
@@ -0,0 +1,23 @@
+package geom
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRelativize(t *testing.T) {
+	for _, facing := range DirectionTypeValues() {
+		for _, relative := range RelativeDirectionValues() {
+			t.Run(fmt.Sprintf("%v-%v", facing, relative), func(t *testing.T) {
+				actual := Actualize(facing, relative)
+				if got := Relativize(facing, actual); got != relative {
+					t.Errorf("want %v, got %v", relative, got)
+				}
+			})
+		}
+	}
+
+	if got := Relativize(S, N); got != Behind {
+		t.Errorf("want S facing and N actual to be Behind, got %v", got)
+	}
+}
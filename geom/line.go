@@ -0,0 +1,93 @@
+package geom
+
+import "math"
+
+// fractionalCube is a point between the centers of hexes, in cube
+// coordinates.
+type fractionalCube struct {
+	x, y, z float64
+}
+
+// round the fractionalCube to the Cube of the hex that it is inside.
+func (c fractionalCube) round() Cube {
+	// Round each axis, then recalculate whichever axis was rounded furthest
+	// so that the coordinates still sum to zero.
+	rx, ry, rz := math.Round(c.x), math.Round(c.y), math.Round(c.z)
+	dx, dy, dz := math.Abs(rx-c.x), math.Abs(ry-c.y), math.Abs(rz-c.z)
+	if dx > dy && dx > dz {
+		rx = -ry - rz
+	} else if dy > dz {
+		ry = -rx - rz
+	} else {
+		rz = -rx - ry
+	}
+	return Cube{int(rx), int(ry), int(rz)}
+}
+
+// nudge is added to the ends of a line so that lines running exactly along
+// the edge between two hexes consistently fall to one side.
+const nudge = 1e-6
+
+func line(a, b Cube, epsilon float64) []Cube {
+	n := a.Distance(b)
+	fa := fractionalCube{float64(a.X) + epsilon, float64(a.Y) + epsilon, float64(a.Z) - 2*epsilon}
+	fb := fractionalCube{float64(b.X) + epsilon, float64(b.Y) + epsilon, float64(b.Z) - 2*epsilon}
+
+	result := make([]Cube, 0, n+1)
+	result = append(result, a)
+	for i := 1; i < n; i++ {
+		t := float64(i) / float64(n)
+		result = append(result, fractionalCube{
+			fa.x + (fb.x-fa.x)*t,
+			fa.y + (fb.y-fa.y)*t,
+			fa.z + (fb.z-fa.z)*t,
+		}.round())
+	}
+	if n > 0 {
+		result = append(result, b)
+	}
+	return result
+}
+
+// CubeLine returns the Cubes that a straight line from a to b passes through,
+// including a and b. Each Cube in the line is adjacent to the next.
+func CubeLine(a, b Cube) []Cube {
+	return line(a, b, nudge)
+}
+
+// Line returns the Keys that a straight line from a to b passes through,
+// including a and b. Each Key in the line is adjacent to the next.
+func Line(a, b Key) []Key {
+	return toKeys(CubeLine(a.Cube(), b.Cube()))
+}
+
+func toKeys(cubes []Cube) []Key {
+	result := make([]Key, len(cubes))
+	for i, c := range cubes {
+		result[i] = c.Key()
+	}
+	return result
+}
+
+// LineOfSight determines whether b is visible from a, where blocks reports
+// whether a Key obstructs vision. The Keys at either end of the line never
+// obstruct. When the line runs exactly between two hexes, then only one side
+// needs to be clear. When b is not visible, the obstructing Keys are returned.
+func LineOfSight(a, b Key, blocks func(Key) bool) (visible bool, obstructions []Key) {
+	for i, epsilon := range []float64{nudge, -nudge} {
+		var found []Key
+		l := line(a.Cube(), b.Cube(), epsilon)
+		for j := 1; j < len(l)-1; j++ {
+			if k := l[j].Key(); blocks(k) {
+				found = append(found, k)
+			}
+		}
+		if len(found) == 0 {
+			return true, nil
+		}
+		if i == 0 {
+			obstructions = found
+		}
+	}
+	return false, obstructions
+}
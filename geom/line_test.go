@@ -0,0 +1,94 @@
+package geom
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLine(t *testing.T) {
+	for am := -3; am <= 3; am++ {
+		for an := -3; an <= 3; an++ {
+			for bm := -3; bm <= 3; bm++ {
+				for bn := -3; bn <= 3; bn++ {
+					a, b := Key{am, an}, Key{bm, bn}
+					l := Line(a, b)
+					if len(l) != a.HexesFrom(b)+1 {
+						t.Fatalf("%v to %v: want %d keys, got %v", a, b, a.HexesFrom(b)+1, l)
+					}
+					if l[0] != a || l[len(l)-1] != b {
+						t.Fatalf("%v to %v: want line to start and end on a and b, got %v", a, b, l)
+					}
+					for i := 1; i < len(l); i++ {
+						if l[i-1].HexesFrom(l[i]) != 1 {
+							t.Fatalf("%v to %v: want contiguous keys, got %v", a, b, l)
+						}
+						if a.HexesFrom(l[i]) != i {
+							t.Fatalf("%v to %v: want each key to step further from a, got %v", a, b, l)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Lines along the six directions pass through each step in that direction.
+	for _, dir := range DirectionTypeValues() {
+		for _, a := range []Key{{0, 0}, {1, 0}} {
+			t.Run(fmt.Sprintf("%v-%v", a, dir), func(t *testing.T) {
+				want := []Key{a}
+				for i := 0; i < 4; i++ {
+					want = append(want, want[len(want)-1].ToDirection(dir))
+				}
+				got := Line(a, want[len(want)-1])
+				if fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("want %v, got %v", want, got)
+				}
+			})
+		}
+	}
+}
+
+func TestLineOfSight(t *testing.T) {
+	blockedBy := func(keys ...Key) func(Key) bool {
+		return func(k Key) bool {
+			for _, b := range keys {
+				if k == b {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	tests := []struct {
+		name     string
+		a, b     Key
+		blockers []Key
+		want     bool
+	}{
+		{"clear", Key{0, 0}, Key{0, 4}, nil, true},
+		{"blocked", Key{0, 0}, Key{0, 4}, []Key{{0, 2}}, false},
+		{"ends never block", Key{0, 0}, Key{0, 4}, []Key{{0, 0}, {0, 4}}, true},
+		{"adjacent", Key{0, 0}, Key{1, 0}, []Key{{0, 0}, {1, 0}}, true},
+		{"blocker off the line", Key{0, 0}, Key{0, 4}, []Key{{1, 2}}, true},
+
+		// From 0,0 to 1,-2, the line runs exactly between 0,-1 and 1,-1.
+		{"edge with one side blocked", Key{0, 0}, Key{1, -2}, []Key{{0, -1}}, true},
+		{"edge with other side blocked", Key{0, 0}, Key{1, -2}, []Key{{1, -1}}, true},
+		{"edge with both sides blocked", Key{0, 0}, Key{1, -2}, []Key{{0, -1}, {1, -1}}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, obstructions := LineOfSight(tc.a, tc.b, blockedBy(tc.blockers...))
+			if got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+			if !got && len(obstructions) == 0 {
+				t.Errorf("want obstructions when not visible")
+			}
+			if back, _ := LineOfSight(tc.b, tc.a, blockedBy(tc.blockers...)); back != got {
+				t.Errorf("want line of sight to be symmetric, got %t backward", back)
+			}
+		})
+	}
+}
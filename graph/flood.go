@@ -0,0 +1,69 @@
+package graph
+
+import "math"
+
+// Reachable is the result of a Flood. It holds every Vertex that could be
+// reached from the start, the cheapest cost to reach each of them, and the
+// Vertex each one is best reached from.
+type Reachable struct {
+	Start Vertex
+
+	// Costs of reaching each reachable Vertex from Start.
+	Costs map[Vertex]float64
+
+	// Origins is the predecessor tree, mapping each reachable Vertex to the
+	// Vertex before it on the cheapest path from Start. Start has no origin.
+	Origins map[Vertex]Vertex
+}
+
+// Contains determines whether v is reachable.
+func (r *Reachable) Contains(v Vertex) bool {
+	_, ok := r.Costs[v]
+	return ok
+}
+
+// Path returns the cheapest path from Start to goal, in the same form as
+// Searcher.Search. It returns nil when goal is not reachable.
+func (r *Reachable) Path(goal Vertex) []Step {
+	if !r.Contains(goal) {
+		return nil
+	}
+	return reconstruct(r.Origins, r.Costs, goal)
+}
+
+// Flood finds every Vertex that can be reached from start for no more than
+// budget, using Dijkstra's algorithm. Use math.Inf(1) as the budget to find
+// everything that is reachable at all.
+func Flood(cost CostFunc, edge EdgeFunc, start Vertex, budget float64) *Reachable {
+	r := Reachable{
+		Start:   start,
+		Costs:   map[Vertex]float64{start: 0},
+		Origins: map[Vertex]Vertex{},
+	}
+
+	closed := map[Vertex]struct{}{}
+	open := newPriorityQueue()
+	open.Push(start, 0)
+
+	for open.Len() > 0 {
+		current := open.Pop()
+		closed[current] = struct{}{}
+
+		for _, n := range edge(current) {
+			if _, ok := closed[n]; ok {
+				continue
+			}
+			tentative := r.Costs[current] + cost(current, n)
+			if tentative > budget || math.IsInf(tentative, 1) {
+				continue
+			}
+			if known, ok := r.Costs[n]; ok && tentative >= known {
+				continue
+			}
+			r.Costs[n] = tentative
+			r.Origins[n] = current
+			open.Push(n, tentative)
+		}
+	}
+	return &r
+}
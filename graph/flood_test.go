@@ -0,0 +1,186 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/griffithsh/squads/geom"
+)
+
+func TestFlood(t *testing.T) {
+	// The same graph as TestSearch1.
+	A, B, C, D := node{"A", 34, 92}, node{"B", 65, 113}, node{"C", 94, 49}, node{"D", 122, 115}
+	costs := func(v1, v2 Vertex) float64 {
+		a, b := v1.(node), v2.(node)
+		if (a.name == "A" && b.name == "C") || (a.name == "C" && b.name == "A") {
+			return 5
+		}
+		return 10
+	}
+	edges := func(v Vertex) []Vertex {
+		switch v.(node).name {
+		case "A":
+			return []Vertex{B, C}
+		case "B":
+			return []Vertex{A, D}
+		case "C":
+			return []Vertex{A, D}
+		case "D":
+			return []Vertex{B, C}
+		}
+		return []Vertex{}
+	}
+
+	r := Flood(costs, edges, A, math.Inf(1))
+	want := map[Vertex]float64{A: 0, B: 10, C: 5, D: 15}
+	if len(r.Costs) != len(want) {
+		t.Errorf("want %d reachable, got %d", len(want), len(r.Costs))
+	}
+	for v, cost := range want {
+		if r.Costs[v] != cost {
+			t.Errorf("%s: want cost %f, got %f", v.(node).name, cost, r.Costs[v])
+		}
+	}
+	if r.Origins[D] != C {
+		t.Errorf("want D reached from C, got %v", r.Origins[D])
+	}
+	if _, ok := r.Origins[A]; ok {
+		t.Errorf("want start to have no origin")
+	}
+	path := r.Path(D)
+	if len(path) != 3 || path[0] != (Step{A, 0}) || path[1] != (Step{C, 5}) || path[2] != (Step{D, 15}) {
+		t.Errorf("want path A, C, D, got %v", path)
+	}
+
+	// A budget excludes anything that costs more.
+	r = Flood(costs, edges, A, 10)
+	if r.Contains(D) || !r.Contains(B) || !r.Contains(C) {
+		t.Errorf("want B and C but not D within budget, got %v", r.Costs)
+	}
+	if r.Path(D) != nil {
+		t.Errorf("want no path to D")
+	}
+}
+
+// hexGraph is a square field of hexes where some hexes are impassable, and
+// others are more expensive to move through, like a combat field.
+type hexGraph struct {
+	size    int
+	blocked map[geom.Key]bool
+	muddy   map[geom.Key]bool
+}
+
+func newHexGraph(size int, seed int64) *hexGraph {
+	rng := rand.New(rand.NewSource(seed))
+	g := hexGraph{
+		size:    size,
+		blocked: map[geom.Key]bool{},
+		muddy:   map[geom.Key]bool{},
+	}
+	for m := 0; m < size; m++ {
+		for n := 0; n < size; n++ {
+			switch roll := rng.Intn(10); {
+			case roll == 0:
+				g.blocked[geom.Key{M: m, N: n}] = true
+			case roll == 1:
+				g.muddy[geom.Key{M: m, N: n}] = true
+			}
+		}
+	}
+	return &g
+}
+
+func (g *hexGraph) cost(_, to Vertex) float64 {
+	k := to.(geom.Key)
+	if g.blocked[k] {
+		return math.Inf(1)
+	}
+	if g.muddy[k] {
+		return 20
+	}
+	return 10
+}
+
+func (g *hexGraph) edges(v Vertex) []Vertex {
+	result := make([]Vertex, 0, 6)
+	for _, k := range v.(geom.Key).Adjacent() {
+		if k.M >= 0 && k.N >= 0 && k.M < g.size && k.N < g.size {
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+func (g *hexGraph) guess(v1, v2 Vertex) float64 {
+	return float64(v1.(geom.Key).HexesFrom(v2.(geom.Key))) * 10
+}
+
+func TestFloodAgreesWithSearch(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		g := newHexGraph(12, seed)
+		start := geom.Key{M: 6, N: 6}
+		delete(g.blocked, start)
+		r := Flood(g.cost, g.edges, start, 60)
+		s := NewSearcher(g.cost, g.edges, g.guess)
+
+		for m := 0; m < g.size; m++ {
+			for n := 0; n < g.size; n++ {
+				goal := geom.Key{M: m, N: n}
+				steps := s.Search(start, goal)
+				searched := math.Inf(1)
+				if steps != nil {
+					searched = steps[len(steps)-1].Cost
+				}
+				if searched > 60 {
+					if r.Contains(goal) {
+						t.Errorf("seed %d %v: want unreachable within budget, got %f", seed, goal, r.Costs[goal])
+					}
+					continue
+				}
+				if !r.Contains(goal) {
+					t.Errorf("seed %d %v: want reachable for %f", seed, goal, searched)
+					continue
+				}
+				if r.Costs[goal] != searched {
+					t.Errorf("seed %d %v: want cost %f, got %f", seed, goal, searched, r.Costs[goal])
+				}
+				path := r.Path(goal)
+				for i := 1; i < len(path); i++ {
+					if path[i].Cost != path[i-1].Cost+g.cost(path[i-1].V, path[i].V) {
+						t.Errorf("seed %d %v: path costs do not accumulate: %v", seed, goal, path)
+					}
+				}
+			}
+		}
+	}
+}
+
+// The benchmarks compare finding every hex reachable with 60 action points on a
+// field the size of a combat, first with a single Flood, then with a Search to
+// each hex of the field.
+
+func BenchmarkFlood(b *testing.B) {
+	g := newHexGraph(24, 1)
+	start := geom.Key{M: 12, N: 12}
+	delete(g.blocked, start)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Flood(g.cost, g.edges, start, 60)
+	}
+}
+
+func BenchmarkSearchEach(b *testing.B) {
+	g := newHexGraph(24, 1)
+	start := geom.Key{M: 12, N: 12}
+	delete(g.blocked, start)
+	s := NewSearcher(g.cost, g.edges, g.guess)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for m := 6; m <= 18; m++ {
+			for n := 6; n <= 18; n++ {
+				s.Search(start, geom.Key{M: m, N: n})
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package graph
+
+import "container/heap"
+
+// queueItem is a Vertex waiting in a priorityQueue.
+type queueItem struct {
+	v        Vertex
+	priority float64
+	index    int
+}
+
+// items implements heap.Interface as a binary min-heap ordered by priority.
+type items []*queueItem
+
+func (q items) Len() int           { return len(q) }
+func (q items) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q items) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *items) Push(x interface{}) {
+	item := x.(*queueItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *items) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
+
+// priorityQueue holds Vertices to visit next, lowest priority first. The
+// priority of a Vertex that is already queued can be lowered by pushing it
+// again.
+type priorityQueue struct {
+	heap    items
+	indexOf map[Vertex]*queueItem
+}
+
+func newPriorityQueue() *priorityQueue {
+	return &priorityQueue{
+		indexOf: map[Vertex]*queueItem{},
+	}
+}
+
+// Len is how many Vertices are queued.
+func (pq *priorityQueue) Len() int {
+	return len(pq.heap)
+}
+
+// Contains determines if v is queued.
+func (pq *priorityQueue) Contains(v Vertex) bool {
+	_, ok := pq.indexOf[v]
+	return ok
+}
+
+// Push adds v to the queue, or updates its priority if it is already queued.
+func (pq *priorityQueue) Push(v Vertex, priority float64) {
+	if item, ok := pq.indexOf[v]; ok {
+		item.priority = priority
+		heap.Fix(&pq.heap, item.index)
+		return
+	}
+	item := &queueItem{v: v, priority: priority}
+	pq.indexOf[v] = item
+	heap.Push(&pq.heap, item)
+}
+
+// Peek returns the lowest priority in the queue without removing it.
+func (pq *priorityQueue) Peek() float64 {
+	return pq.heap[0].priority
+}
+
+// Pop removes and returns the Vertex with the lowest priority.
+func (pq *priorityQueue) Pop() Vertex {
+	item := heap.Pop(&pq.heap).(*queueItem)
+	delete(pq.indexOf, item.v)
+	return item.v
+}
@@ -25,58 +25,39 @@ func NewSearcher(cost CostFunc, edge EdgeFunc, guess Heuristic) *Searcher {
 // the start and the goal Vertices. It returns nil when no path is available.
 func (s *Searcher) Search(start, goal Vertex) []Step {
 	closed := map[Vertex]interface{}{}
-	open := map[Vertex]interface{}{
-		start: struct{}{},
-	}
+	open := newPriorityQueue()
+	open.Push(start, s.heur(start, goal))
+
 	origins := map[Vertex]Vertex{}
 	costs := map[Vertex]float64{
 		start: 0,
 	}
-	guesses := map[Vertex]float64{
-		start: s.heur(start, goal),
-	}
 
-	for len(open) > 0 {
-		low := math.MaxFloat64
-		var current Vertex
-		for k := range open {
-			if guesses[k] < low {
-				current = k
-				low = guesses[k]
-			}
-		}
-		var unassigned Vertex
-		if current == unassigned {
-			// We've gone through the entire open list without finding a path.
+	for open.Len() > 0 {
+		if math.IsInf(open.Peek(), 1) {
+			// Everything left in the open list is unreachable.
 			return nil
 		}
+		current := open.Pop()
+
 		if current == goal {
-			m := map[Vertex]Vertex{}
-			for k, v := range origins {
-				m[k] = v
-			}
-			return reconstruct(m, costs, goal)
+			return reconstruct(origins, costs, goal)
 		}
 
-		delete(open, current)
 		closed[current] = struct{}{}
 
 		for _, n := range s.adj(current) {
 			if _, ok := closed[n]; ok {
 				continue
 			}
-
 			tentative := costs[current] + s.cost(current, n)
 
-			if _, ok := open[n]; !ok {
-				open[n] = struct{}{}
-			} else if tentative >= costs[n] {
+			if open.Contains(n) && tentative >= costs[n] {
 				continue
 			}
-
 			origins[n] = current
 			costs[n] = tentative
-			guesses[n] = costs[n] + s.heur(n, goal)
+			open.Push(n, costs[n]+s.heur(n, goal))
 		}
 	}
 	return nil
@@ -0,0 +1,39 @@
+package loot
+
+import "github.com/griffithsh/squads/game/item"
+
+// Haul is the loot that has been rolled from Tables.
+type Haul struct {
+	Gold        int
+	Consumables map[string]int
+	Items       []*item.Instance
+}
+
+// Add the loot of other to the Haul.
+func (h *Haul) Add(other Haul) {
+	h.Gold += other.Gold
+	if h.Consumables == nil {
+		h.Consumables = map[string]int{}
+	}
+	for code, count := range other.Consumables {
+		h.Consumables[code] += count
+	}
+	h.Items = append(h.Items, other.Items...)
+}
+
+// Empty reports whether there is nothing in the Haul.
+func (h Haul) Empty() bool {
+	return h.Gold == 0 && len(h.Consumables) == 0 && len(h.Items) == 0
+}
+
+// Stash the Gold and Consumables of the Haul in inv. Items are left to be
+// assigned to the members of the Squad.
+func (h Haul) Stash(inv *item.Inventory) {
+	inv.Gold += h.Gold
+	if inv.Consumables == nil {
+		inv.Consumables = map[string]int{}
+	}
+	for code, count := range h.Consumables {
+		inv.Consumables[code] += count
+	}
+}
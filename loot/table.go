@@ -0,0 +1,84 @@
+package loot
+
+import (
+	"math/rand"
+
+	"github.com/griffithsh/squads/game/item"
+)
+
+// Range is an inclusive range of quantities.
+type Range struct {
+	Min, Max int
+}
+
+func (r Range) roll(rng *rand.Rand) int {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return r.Min + rng.Intn(r.Max-r.Min+1)
+}
+
+// Drop is one thing that might be found on a defeated baddy. A Drop can award
+// Gold, a Count of a Consumable, an Item, or any combination of them.
+type Drop struct {
+	// Chance is the probability, between 0 and 1, of the Drop being awarded.
+	Chance float64
+
+	Gold       Range
+	Consumable string
+	Count      Range
+	Item       *item.Instance
+}
+
+// Tier is the Drops of a Table that apply to Squads of at least MinLevel.
+type Tier struct {
+	MinLevel int
+	Drops    []Drop
+}
+
+// Table describes the loot that a baddy constructed from the Recipe named
+// Baddy drops when its Squad is defeated.
+type Table struct {
+	Baddy string
+	Tiers []Tier
+}
+
+// tier finds the highest Tier that a Squad of level qualifies for.
+func (t Table) tier(level int) *Tier {
+	var result *Tier
+	for i, tier := range t.Tiers {
+		if tier.MinLevel > level {
+			continue
+		}
+		if result == nil || tier.MinLevel > result.MinLevel {
+			result = &t.Tiers[i]
+		}
+	}
+	return result
+}
+
+// Roll the Table for a baddy from a Squad of level.
+func (t Table) Roll(rng *rand.Rand, level int) Haul {
+	result := Haul{Consumables: map[string]int{}}
+	tier := t.tier(level)
+	if tier == nil {
+		return result
+	}
+	for _, drop := range tier.Drops {
+		if rng.Float64() >= drop.Chance {
+			continue
+		}
+		result.Gold += drop.Gold.roll(rng)
+		if drop.Consumable != "" {
+			count := drop.Count.roll(rng)
+			if count < 1 {
+				count = 1
+			}
+			result.Consumables[drop.Consumable] += count
+		}
+		if drop.Item != nil {
+			result.Items = append(result.Items, drop.Item.Clone())
+		}
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package loot
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/griffithsh/squads/game/item"
+)
+
+func TestRoll(t *testing.T) {
+	table := Table{
+		Baddy: "Wolf",
+		Tiers: []Tier{
+			{MinLevel: 5, Drops: []Drop{
+				{Chance: 1, Gold: Range{Min: 10, Max: 10}},
+				{Chance: 1, Item: &item.Instance{Name: "Fang", Modifiers: map[item.Modifier]float64{item.AgilityModifier: 1}}},
+			}},
+			{MinLevel: 1, Drops: []Drop{
+				{Chance: 1, Gold: Range{Min: 1, Max: 3}},
+				{Chance: 1, Consumable: "pelt"},
+				{Chance: 0, Consumable: "never"},
+			}},
+		},
+	}
+	rng := rand.New(rand.NewSource(1))
+
+	if got := table.Roll(rng, 0); !got.Empty() {
+		t.Errorf("want nothing below the lowest tier, got %+v", got)
+	}
+
+	low := table.Roll(rng, 4)
+	if low.Gold < 1 || low.Gold > 3 {
+		t.Errorf("want 1-3 gold from the low tier, got %d", low.Gold)
+	}
+	if low.Consumables["pelt"] != 1 || low.Consumables["never"] != 0 || len(low.Items) != 0 {
+		t.Errorf("unexpected low tier haul %+v", low)
+	}
+
+	high := table.Roll(rng, 9)
+	if high.Gold != 10 || len(high.Items) != 1 || len(high.Consumables) != 0 {
+		t.Fatalf("unexpected high tier haul %+v", high)
+	}
+	high.Items[0].Modifiers[item.AgilityModifier] = 99
+	if again := table.Roll(rng, 9); again.Items[0].Modifiers[item.AgilityModifier] != 1 {
+		t.Errorf("want rolled items independent of the table")
+	}
+
+	low.Add(high)
+	if low.Gold < 11 || len(low.Items) != 1 || low.Consumables["pelt"] != 1 {
+		t.Errorf("unexpected combined haul %+v", low)
+	}
+}
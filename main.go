@@ -15,6 +15,7 @@ import (
 
 func main() {
 	cpuProfile := flag.String("cpuprofile", "", "write cpu profile to file")
+	transcript := flag.String("transcript", "", "append a transcript of every combat to file, as JSON lines if it ends in .json")
 	flag.Parse()
 	if *cpuProfile != "" {
 		f, err := os.Create(*cpuProfile)
@@ -34,6 +35,7 @@ func main() {
 		fmt.Printf("setup system: %v\n", err)
 		os.Exit(1)
 	}
+	s.transcript = *transcript
 
 	ebiten.SetWindowSize(w, h)
 	if err := ebiten.RunGame(s); err == errExitGame {
@@ -11,10 +11,14 @@ const (
 	CostsMana
 	CostsExhaustionPercent
 	CostsHealthSacrificePercent
+
+	// CostsPreparation delays the next turn of the user by the amount of
+	// preparation.
+	CostsPreparation
 )
 
 func CostTypeFromString(s string) *CostType {
-	for i := 0; i <= int(CostsHealthSacrificePercent); i++ {
+	for i := 0; i <= int(CostsPreparation); i++ {
 		c := CostType(i)
 
 		if c.String() == s {
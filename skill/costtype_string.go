@@ -12,11 +12,12 @@ func _() {
 	_ = x[CostsMana-1]
 	_ = x[CostsExhaustionPercent-2]
 	_ = x[CostsHealthSacrificePercent-3]
+	_ = x[CostsPreparation-4]
 }
 
-const _CostType_name = "CostsActionPointsCostsManaCostsExhaustionPercentCostsHealthSacrificePercent"
+const _CostType_name = "CostsActionPointsCostsManaCostsExhaustionPercentCostsHealthSacrificePercentCostsPreparation"
 
-var _CostType_index = [...]uint8{0, 17, 26, 48, 75}
+var _CostType_index = [...]uint8{0, 17, 26, 48, 75, 91}
 
 func (i CostType) String() string {
 	if i < 0 || i >= CostType(len(_CostType_index)-1) {
@@ -25,11 +25,38 @@ type Description struct {
 
 	Costs map[CostType]int
 
+	// Cooldown that must elapse after using the skill before it can be used
+	// again.
+	Cooldown Cooldown
+
+	// Charges limits how many times the skill can be used in a single combat.
+	// Zero means that the skill can be used without limit.
+	Charges int
+
 	// AttackChanceToHitModifier multiplies the base chance to hit of the skill.
 	// A value of zero does not modify the chance to hit. A value of 0.1
 	// improves the chance to hit by 10%. A value of -0.5 halves the chance to
 	// hit.
 	AttackChanceToHitModifier float64
+
+	// Reaction triggers the skill to be used out of turn. A skill with a
+	// Reaction can still be used on the turn of its user.
+	Reaction Reaction
+}
+
+// Cooldown is the time that must pass after a skill is used before it can be
+// used again. When both Turns and Preparation are set, both must elapse.
+type Cooldown struct {
+	// Turns is the number of the user's turns that must begin.
+	Turns int
+
+	// Preparation is the amount of preparation that the user must accumulate.
+	Preparation int
+}
+
+// Elapsed returns whether there is no cooldown remaining.
+func (c Cooldown) Elapsed() bool {
+	return c.Turns <= 0 && c.Preparation <= 0
 }
 
 // IsAttack returns whether a skill is an attack or not.
@@ -67,8 +67,34 @@ type DefileEffect struct{}
 type SpawnParticipantEffect struct {
 	Profession string
 	Level      Operations
+
+	// Lifetime is how long the spawned participant lasts before it crumbles.
+	Lifetime Lifetime
+
+	// Team overrides which team the spawned participant fights for.
+	Team SpawnTeam
 }
 
+// Lifetime limits how long a spawned participant lasts, in its own turns or
+// in preparation elapsed. A zero Lifetime lasts for as long as the user of the
+// skill stands.
+type Lifetime struct {
+	Turns       int
+	Preparation int
+}
+
+// SpawnTeam enumerates the teams that a spawned participant can fight for.
+type SpawnTeam int
+
+const (
+	// UserTeam spawns the participant on the team of the user of the skill.
+	UserTeam SpawnTeam = iota
+
+	// WildTeam spawns the participant on a new team of its own, hostile to
+	// everyone else.
+	WildTeam
+)
+
 // InjuryType enumerates injuries.
 type InjuryType int
 
@@ -0,0 +1,23 @@
+package skill
+
+//go:generate go run github.com/dmarkham/enumer -type=Reaction -json
+
+// Reaction is what triggers a skill to be used out of turn.
+type Reaction int
+
+const (
+	// NoReaction skills are only used on the turn of their user.
+	NoReaction Reaction = iota
+
+	// OpportunityReaction skills are used against an enemy that moves away
+	// from the user.
+	OpportunityReaction
+
+	// CounterReaction skills are used against an enemy that hits the user in
+	// melee.
+	CounterReaction
+
+	// OverwatchReaction skills are used against an enemy that moves into a hex
+	// the user could target with the skill.
+	OverwatchReaction
+)
@@ -5,6 +5,8 @@ import (
 	"image"
 	"math/rand"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/griffithsh/squads/data"
@@ -14,6 +16,8 @@ import (
 	"github.com/griffithsh/squads/game/combat"
 	"github.com/griffithsh/squads/game/embark"
 	"github.com/griffithsh/squads/game/overworld"
+	"github.com/griffithsh/squads/game/rewards"
+	"github.com/griffithsh/squads/loot"
 	"github.com/griffithsh/squads/output"
 	"github.com/griffithsh/squads/ui"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -38,11 +42,18 @@ type squads struct {
 	embark    *embark.Manager
 	overworld *overworld.Manager
 	combat    *combat.Manager
+	rewards   *rewards.Manager
 
+	archive   *data.Archive
+	rng       *rand.Rand
 	mgr       *ecs.World
 	camera    *game.Camera
 	lastMouse image.Point
 	last      time.Time
+
+	// transcript is the file that the log of every combat is appended to, if
+	// any.
+	transcript string
 }
 
 func newSquads(w, h int) (*squads, error) {
@@ -72,9 +83,12 @@ func newSquads(w, h int) (*squads, error) {
 		embark:     embark.NewManager(mgr, bus, archive),
 		overworld:  overworld.NewManager(mgr, bus, archive),
 		combat:     combat.NewManager(mgr, camera, bus, archive),
+		rewards:    rewards.NewManager(mgr, bus),
 
-		mgr:    mgr,
-		camera: camera,
+		archive: archive,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		mgr:     mgr,
+		camera:  camera,
 
 		fonts:        game.NewFontSystem(mgr),
 		hierarchy:    ecs.NewParentSystem(mgr),
@@ -85,22 +99,60 @@ func newSquads(w, h int) (*squads, error) {
 		uiSystem:     ui.NewUISystem(mgr, bus),
 	}
 	bus.Subscribe(game.CombatConcluded{}.Type(), func(et event.Typer) {
+		if s.transcript != "" {
+			if err := s.writeTranscript(); err != nil {
+				fmt.Fprintf(os.Stderr, "write transcript: %v\n", err)
+			}
+		}
 		s.combat.End()
 
 		// Handle results of combat.
 		ev := et.(*game.CombatConcluded)
+
+		// Characters advance before any squads are removed, so that the level
+		// up events can be presented on the overworld.
+		for e, progression := range ev.Progression {
+			char := mgr.Component(e, "Character").(*game.Character)
+			levels, masteries := char.Advance(progression)
+			if levels > 0 {
+				bus.Publish(&game.CharacterLevelledUp{
+					Character: e,
+					Level:     char.Level,
+				})
+			}
+			for _, mastery := range masteries {
+				bus.Publish(&game.MasteryImproved{
+					Character: e,
+					Mastery:   mastery,
+					Level:     char.Masteries[mastery],
+				})
+			}
+		}
+
+		// The fallen that escaping Squads left behind are lost.
+		for _, e := range mgr.Get([]string{"Squad"}) {
+			squad := mgr.Component(e, "Squad").(*game.Squad)
+			squad.Members = slices.DeleteFunc(squad.Members, func(member ecs.Entity) bool {
+				return slices.Contains(ev.LeftBehind, member)
+			})
+			for _, member := range ev.LeftBehind {
+				delete(squad.Formation, member)
+			}
+		}
+		for _, e := range ev.LeftBehind {
+			mgr.DestroyEntity(e)
+		}
+
+		// Loot is rolled before the defeated baddy squads go away.
+		victor, haul := s.rollLoot(ev.Results)
+
 		for e, result := range ev.Results {
 			if mgr.HasTag(e, "player") {
 				switch result {
 				case game.Escaped:
-					// player escaped, others are removed
-					for otherEntity := range ev.Results {
-						if e == otherEntity {
-							continue
-						}
-						mgr.DestroyEntity(otherEntity)
-
-					}
+					// player escaped, and the others are left where they
+					// are. The overworld has moved the player's squad back
+					// to where it came from.
 				case game.Defeated:
 					// game is over
 					mgr.DestroyEntity(e)
@@ -114,12 +166,20 @@ func newSquads(w, h int) (*squads, error) {
 		// force cascade of deleted components
 		s.hierarchy.Update()
 
+		if haul.Empty() {
+			s.overworld.Enable()
+			return
+		}
+		s.rewards.Begin(victor, haul)
+	})
+	bus.Subscribe(rewards.Claimed{}.Type(), func(event.Typer) {
+		s.rewards.End()
 		s.overworld.Enable()
 	})
 	bus.Subscribe(overworld.CombatInitiated{}.Type(), func(t event.Typer) {
 		s.overworld.Disable()
 		ev := t.(*overworld.CombatInitiated)
-		s.combat.Begin(ev.Squads)
+		s.combat.Begin(ev.Squads, ev.Terrain, ev.Seed)
 	})
 	bus.Subscribe(embark.Embarked{}.Type(), func(t event.Typer) {
 		s.embark.End()
@@ -153,6 +213,64 @@ func newSquads(w, h int) (*squads, error) {
 	return &s, nil
 }
 
+// writeTranscript appends the log of the combat that has just concluded to the
+// transcript file.
+func (s *squads) writeTranscript() error {
+	f, err := os.OpenFile(s.transcript, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open: %v", err)
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(s.transcript, ".json") {
+		return s.combat.Log().WriteJSON(f)
+	}
+	if err := s.combat.Log().WriteText(f); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(f)
+	return err
+}
+
+// rollLoot rolls the loot tables of the baddies in the squads that the player
+// defeated. It returns the victorious player squad and what it won.
+func (s *squads) rollLoot(results map[ecs.Entity]game.CombatResult) (ecs.Entity, loot.Haul) {
+	var victor ecs.Entity
+	for e, result := range results {
+		if s.mgr.HasTag(e, "player") && result == game.Victorious {
+			victor = e
+		}
+	}
+	haul := loot.Haul{}
+	if victor == 0 {
+		return 0, haul
+	}
+
+	for e, result := range results {
+		if s.mgr.HasTag(e, "player") || result != game.Defeated {
+			continue
+		}
+		squad := s.mgr.Component(e, "Squad").(*game.Squad)
+
+		// The level of a squad is the level of its strongest member.
+		level := 0
+		for _, member := range squad.Members {
+			if char := s.mgr.Component(member, "Character").(*game.Character); char.Level > level {
+				level = char.Level
+			}
+		}
+		for _, member := range squad.Members {
+			char := s.mgr.Component(member, "Character").(*game.Character)
+			table, ok := s.archive.LootTable(char.Baddy)
+			if !ok {
+				continue
+			}
+			haul.Add(table.Roll(s.rng, level))
+		}
+	}
+	return victor, haul
+}
+
 func (s *squads) setScreenSize(w, h int) {
 	s.bus.Publish(&game.WindowSizeChanged{
 		OldW: s.camera.GetW(),
@@ -236,14 +354,29 @@ func (s *squads) Update() error {
 		s.setScreenSize(1024, 768)
 	}
 
+	// F fast-forwards combat, and I toggles instant computer turns.
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		s.combat.CycleSpeed()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyI) {
+		s.combat.ToggleInstant()
+	}
+
 	x, y := ebiten.CursorPosition()
 
 	if s.lastMouse.X != x || s.lastMouse.Y != y {
 		s.combat.MousePosition(x, y)
+		s.bus.Publish(&ui.UIHover{
+			AbsoluteX: float64(x),
+			AbsoluteY: float64(y),
+		})
 		s.lastMouse.X = x
 		s.lastMouse.Y = y
 	}
 
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		s.combat.MousePressed(x, y)
+	}
 	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
 		wx, wy := s.camera.ScreenToWorld(x, y)
 		s.bus.Publish(&ui.UIInteract{
@@ -21,11 +21,17 @@ const (
 	// LinearFromOrigin uses a direction and length to indicate which hexes are painted.
 	LinearFromOrigin
 
-	// Arc? Radius (probably 1, or maye 2?), Length (how many hexes to paint
-	// around the circle, negative for anticlockwise), Begin (if you want the
-	// selected adjacent hex, as well as the ones to each side, you can begin on
-	// -1 and use length 3) .
-	// Arc
+	// Arc paints part of the ring that is ArcRadius hexes from the origin.
+	// ArcLength is how many hexes to paint around the ring, negative for
+	// anticlockwise. ArcBegin is where to start relative to the hex on the
+	// ring in the Direction of the selection; if you want the selected
+	// adjacent hex, as well as the ones to each side, you can begin on -1 and
+	// use length 3.
+	Arc
+
+	// Cone paints a 120 degree cone that spreads out from the origin in the
+	// Direction of the selection, between MinRange and MaxRange hexes away.
+	Cone
 
 	// None means no selected hexes. This could be used for ... what? Nothing?
 	// None
@@ -40,4 +46,13 @@ type Brush struct {
 
 	LinearExtent    int
 	LinearDirection geom.RelativeDirection
+
+	// Direction orients Arc and Cone brushes, relative to the direction from
+	// the origin to the selection.
+	Direction geom.RelativeDirection
+
+	// Only for Arc.
+	ArcRadius int
+	ArcBegin  int
+	ArcLength int
 }
@@ -8,11 +8,11 @@ import (
 	"strings"
 )
 
-const _BrushTypeName = "SingleHexWithinRangeOfTargetWithinRangeOfOriginLinearFromOrigin"
+const _BrushTypeName = "SingleHexWithinRangeOfTargetWithinRangeOfOriginLinearFromOriginArcCone"
 
-var _BrushTypeIndex = [...]uint8{0, 9, 28, 47, 63}
+var _BrushTypeIndex = [...]uint8{0, 9, 28, 47, 63, 66, 70}
 
-const _BrushTypeLowerName = "singlehexwithinrangeoftargetwithinrangeoforiginlinearfromorigin"
+const _BrushTypeLowerName = "singlehexwithinrangeoftargetwithinrangeoforiginlinearfromoriginarccone"
 
 func (i BrushType) String() string {
 	if i < 0 || i >= BrushType(len(_BrushTypeIndex)-1) {
@@ -29,9 +29,11 @@ func _BrushTypeNoOp() {
 	_ = x[WithinRangeOfTarget-(1)]
 	_ = x[WithinRangeOfOrigin-(2)]
 	_ = x[LinearFromOrigin-(3)]
+	_ = x[Arc-(4)]
+	_ = x[Cone-(5)]
 }
 
-var _BrushTypeValues = []BrushType{SingleHex, WithinRangeOfTarget, WithinRangeOfOrigin, LinearFromOrigin}
+var _BrushTypeValues = []BrushType{SingleHex, WithinRangeOfTarget, WithinRangeOfOrigin, LinearFromOrigin, Arc, Cone}
 
 var _BrushTypeNameToValueMap = map[string]BrushType{
 	_BrushTypeName[0:9]:        SingleHex,
@@ -42,6 +44,10 @@ var _BrushTypeNameToValueMap = map[string]BrushType{
 	_BrushTypeLowerName[28:47]: WithinRangeOfOrigin,
 	_BrushTypeName[47:63]:      LinearFromOrigin,
 	_BrushTypeLowerName[47:63]: LinearFromOrigin,
+	_BrushTypeName[63:66]:      Arc,
+	_BrushTypeLowerName[63:66]: Arc,
+	_BrushTypeName[66:70]:      Cone,
+	_BrushTypeLowerName[66:70]: Cone,
 }
 
 var _BrushTypeNames = []string{
@@ -49,6 +55,8 @@ var _BrushTypeNames = []string{
 	_BrushTypeName[9:28],
 	_BrushTypeName[28:47],
 	_BrushTypeName[47:63],
+	_BrushTypeName[63:66],
+	_BrushTypeName[66:70],
 }
 
 // BrushTypeString retrieves an enum value from the enum constants string name.
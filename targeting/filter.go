@@ -0,0 +1,31 @@
+package targeting
+
+// TargetFilter enumerates the kinds of hexes that a skill may be targeted on.
+type TargetFilter int
+
+//go:generate go run github.com/dmarkham/enumer -type=TargetFilter -json
+
+const (
+	// TargetSelf permits the hex of the user of the skill.
+	TargetSelf TargetFilter = iota
+
+	// TargetAlly permits hexes occupied by a standing character on the same
+	// team as the user, other than the user.
+	TargetAlly
+
+	// TargetEnemy permits hexes occupied by a standing character on another
+	// team.
+	TargetEnemy
+
+	// TargetKnockedDown permits hexes where a knocked down character lies.
+	TargetKnockedDown
+
+	// TargetDefiled permits hexes where a defiled character lies.
+	TargetDefiled
+
+	// TargetEmpty permits hexes without any obstacle or character in them.
+	TargetEmpty
+
+	// TargetPassable permits hexes that a character could move through.
+	TargetPassable
+)
@@ -9,11 +9,16 @@ import (
 type Rule struct {
 	Selectable Selectable
 	Brush      Brush
+
+	// Trajectory determines what can block the skill on its way to the
+	// selected hex.
+	Trajectory Trajectory
 }
 
 // Execute is meant to give you the Keys that should be painted by this rule as
-// executed on the given selection and origin.
-func (r *Rule) Execute(selected, origin geom.Key) (selectable bool, paints []geom.Key) {
+// executed on the given selection and origin. Facing is the direction that the
+// user at the origin is facing.
+func (r *Rule) Execute(selected, origin geom.Key, facing geom.DirectionType) (selectable bool, paints []geom.Key) {
 	// You might need to know the Keys that are permissable selections.
 	// You might need to know the Keys that would be painted by the brush.
 	// It might be impractical to calculate permissable selections in all contexts.
@@ -26,6 +31,18 @@ func (r *Rule) Execute(selected, origin geom.Key) (selectable bool, paints []geo
 		if r.Selectable.MinRange <= distance && r.Selectable.MaxRange >= distance {
 			selectable = true
 		}
+	case SelectLinear:
+		distance := origin.HexesFrom(selected)
+		toward := geom.Actualize(facing, r.Selectable.Direction)
+		if dir, ok := linear(origin, selected); ok && dir == toward && r.Selectable.MinRange <= distance && r.Selectable.MaxRange >= distance {
+			selectable = true
+		}
+	case SelectArc:
+		toward := geom.Actualize(facing, r.Selectable.Direction)
+		selectable = contains(arc(origin, r.Selectable.ArcRadius, toward, r.Selectable.ArcBegin, r.Selectable.ArcLength), selected)
+	case SelectCone:
+		toward := geom.Actualize(facing, r.Selectable.Direction)
+		selectable = contains(cone(origin, toward, r.Selectable.MinRange, r.Selectable.MaxRange), selected)
 	case Untargeted:
 		selectable = true
 	default:
@@ -68,6 +85,14 @@ func (r *Rule) Execute(selected, origin geom.Key) (selectable bool, paints []geo
 			paints = append(paints, k)
 		}
 
+	case Arc:
+		toward := geom.Actualize(geom.FindDirection(origin, selected), r.Brush.Direction)
+		paints = arc(origin, r.Brush.ArcRadius, toward, r.Brush.ArcBegin, r.Brush.ArcLength)
+
+	case Cone:
+		toward := geom.Actualize(geom.FindDirection(origin, selected), r.Brush.Direction)
+		paints = cone(origin, toward, r.Brush.MinRange, r.Brush.MaxRange)
+
 	default:
 		panic(fmt.Sprintf("unhandled BrushType %s", r.Brush.Type))
 	}
@@ -0,0 +1,256 @@
+package targeting
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/griffithsh/squads/geom"
+)
+
+// origins cover both even and odd columns, because neighbouring Keys are
+// calculated differently for each.
+var origins = []geom.Key{{M: 6, N: 6}, {M: 7, N: 6}, {M: 6, N: 7}, {M: 7, N: 7}}
+
+func move(k geom.Key, dir geom.DirectionType, distance int) geom.Key {
+	for i := 0; i < distance; i++ {
+		k = k.ToDirection(dir)
+	}
+	return k
+}
+
+func sorted(keys []geom.Key) []geom.Key {
+	result := append([]geom.Key{}, keys...)
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].M != result[j].M {
+			return result[i].M < result[j].M
+		}
+		return result[i].N < result[j].N
+	})
+	return result
+}
+
+func TestRing(t *testing.T) {
+	for _, origin := range origins {
+		for radius := 1; radius <= 5; radius++ {
+			for _, start := range geom.DirectionTypeValues() {
+				t.Run(fmt.Sprintf("%v-r%d-%v", origin, radius, start), func(t *testing.T) {
+					r := ring(origin, radius, start)
+					if len(r) != 6*radius {
+						t.Fatalf("want %d keys, got %d", 6*radius, len(r))
+					}
+					if r[0] != move(origin, start, radius) {
+						t.Errorf("want ring to begin at %v, got %v", move(origin, start, radius), r[0])
+					}
+					seen := map[geom.Key]struct{}{}
+					for i, k := range r {
+						if d := origin.HexesFrom(k); d != radius {
+							t.Errorf("want %v to be %d from origin, got %d", k, radius, d)
+						}
+						if _, ok := seen[k]; ok {
+							t.Errorf("%v is duplicated", k)
+						}
+						seen[k] = struct{}{}
+
+						next := r[(i+1)%len(r)]
+						if d := k.HexesFrom(next); d != 1 {
+							t.Errorf("want %v and %v to be adjacent, got %d apart", k, next, d)
+						}
+					}
+
+					// The second Key is clockwise of the first.
+					clockwise := geom.DirectionType((int(start) + 1) % 6)
+					if radius == 1 && r[1] != origin.ToDirection(clockwise) {
+						t.Errorf("want ring to proceed clockwise to %v, got %v", origin.ToDirection(clockwise), r[1])
+					}
+
+					// Every start direction yields the same Keys.
+					want := sorted(ring(origin, radius, geom.N))
+					got := sorted(r)
+					for i := range want {
+						if want[i] != got[i] {
+							t.Fatalf("want the same keys as starting from N, got %v and %v", want, got)
+						}
+					}
+				})
+			}
+		}
+	}
+
+	if r := ring(geom.Key{M: 3, N: 3}, 0, geom.N); len(r) != 1 || r[0] != (geom.Key{M: 3, N: 3}) {
+		t.Errorf("want radius zero to be the origin, got %v", r)
+	}
+}
+
+func TestArc(t *testing.T) {
+	for _, origin := range origins {
+		for _, toward := range geom.DirectionTypeValues() {
+			left := geom.Actualize(toward, geom.ForwardLeft)
+			right := geom.Actualize(toward, geom.ForwardRight)
+			t.Run(fmt.Sprintf("%v-%v", origin, toward), func(t *testing.T) {
+				// The selected adjacent hex and the ones to each side.
+				got := arc(origin, 1, toward, -1, 3)
+				want := []geom.Key{origin.ToDirection(left), origin.ToDirection(toward), origin.ToDirection(right)}
+				if fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("sweep: want %v, got %v", want, got)
+				}
+
+				// The hex ahead and one to the side, anticlockwise.
+				got = arc(origin, 1, toward, 0, -2)
+				want = []geom.Key{origin.ToDirection(toward), origin.ToDirection(left)}
+				if fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("anticlockwise: want %v, got %v", want, got)
+				}
+
+				// Arcs longer than the ring are the whole ring.
+				if got := arc(origin, 2, toward, 5, 100); len(got) != 12 {
+					t.Errorf("want the whole ring of 12, got %d", len(got))
+				}
+
+				// Arcs at a larger radius start from the corner of the ring.
+				got = arc(origin, 3, toward, 0, 1)
+				if want := move(origin, toward, 3); got[0] != want {
+					t.Errorf("radius 3: want %v, got %v", want, got[0])
+				}
+			})
+		}
+	}
+}
+
+func TestCone(t *testing.T) {
+	for _, origin := range origins {
+		for _, toward := range geom.DirectionTypeValues() {
+			left := geom.Actualize(toward, geom.ForwardLeft)
+			right := geom.Actualize(toward, geom.ForwardRight)
+			back := geom.Actualize(toward, geom.Behind)
+			t.Run(fmt.Sprintf("%v-%v", origin, toward), func(t *testing.T) {
+				c := cone(origin, toward, 1, 4)
+				if want := 3 + 5 + 7 + 9; len(c) != want {
+					t.Errorf("want %d keys, got %d", want, len(c))
+				}
+				for d := 1; d <= 4; d++ {
+					for _, k := range []geom.Key{move(origin, toward, d), move(origin, left, d), move(origin, right, d)} {
+						if !contains(c, k) {
+							t.Errorf("want %v at distance %d in the cone", k, d)
+						}
+					}
+					if contains(c, move(origin, back, d)) {
+						t.Errorf("want %v behind the origin to be outside the cone", move(origin, back, d))
+					}
+				}
+				for _, k := range c {
+					if d := origin.HexesFrom(k); d < 1 || d > 4 {
+						t.Errorf("want %v within 1-4 hexes, got %d", k, d)
+					}
+				}
+				if contains(c, origin) {
+					t.Errorf("want the origin excluded when min range is 1")
+				}
+				if !contains(cone(origin, toward, 0, 1), origin) {
+					t.Errorf("want the origin included when min range is 0")
+				}
+			})
+		}
+	}
+}
+
+func TestLinear(t *testing.T) {
+	for _, origin := range origins {
+		for _, dir := range geom.DirectionTypeValues() {
+			for d := 1; d <= 5; d++ {
+				got, ok := linear(origin, move(origin, dir, d))
+				if !ok || got != dir {
+					t.Errorf("%v: want %v at distance %d to be linear, got %v, %t", origin, dir, d, got, ok)
+				}
+			}
+		}
+		// A hex that is one step off a straight line.
+		offset := move(origin, geom.N, 2).ToDirection(geom.NE)
+		if _, ok := linear(origin, offset); ok {
+			t.Errorf("%v: want %v not to be linear", origin, offset)
+		}
+		if _, ok := linear(origin, origin); ok {
+			t.Errorf("%v: want the origin not to be linear with itself", origin)
+		}
+	}
+}
+
+func TestExecuteSelectable(t *testing.T) {
+	for _, origin := range origins {
+		for _, facing := range geom.DirectionTypeValues() {
+			ahead := geom.Actualize(facing, geom.Forward)
+			behind := geom.Actualize(facing, geom.Behind)
+			right := geom.Actualize(facing, geom.ForwardRight)
+
+			for _, tc := range []struct {
+				name     string
+				rule     Rule
+				selected geom.Key
+				want     bool
+			}{
+				{"linear ahead", Rule{Selectable: Selectable{Type: SelectLinear, MinRange: 1, MaxRange: 3}}, move(origin, ahead, 3), true},
+				{"linear behind", Rule{Selectable: Selectable{Type: SelectLinear, MinRange: 1, MaxRange: 3}}, move(origin, behind, 2), false},
+				{"linear right", Rule{Selectable: Selectable{Type: SelectLinear, MinRange: 1, MaxRange: 3}}, move(origin, right, 2), false},
+				{"linear directed behind", Rule{Selectable: Selectable{Type: SelectLinear, Direction: geom.Behind, MinRange: 1, MaxRange: 3}}, move(origin, behind, 2), true},
+				{"linear too far", Rule{Selectable: Selectable{Type: SelectLinear, MinRange: 1, MaxRange: 3}}, move(origin, ahead, 4), false},
+				{"linear too close", Rule{Selectable: Selectable{Type: SelectLinear, MinRange: 2, MaxRange: 3}}, move(origin, ahead, 1), false},
+				{"linear offset", Rule{Selectable: Selectable{Type: SelectLinear, MinRange: 1, MaxRange: 3}}, move(origin, ahead, 2).ToDirection(right), false},
+				{"arc ahead", Rule{Selectable: Selectable{Type: SelectArc, ArcRadius: 1, ArcBegin: -1, ArcLength: 3}}, origin.ToDirection(ahead), true},
+				{"arc right", Rule{Selectable: Selectable{Type: SelectArc, ArcRadius: 1, ArcBegin: -1, ArcLength: 3}}, origin.ToDirection(right), true},
+				{"arc behind", Rule{Selectable: Selectable{Type: SelectArc, ArcRadius: 1, ArcBegin: -1, ArcLength: 3}}, origin.ToDirection(behind), false},
+				{"arc directed behind", Rule{Selectable: Selectable{Type: SelectArc, Direction: geom.Behind, ArcRadius: 1, ArcLength: 1}}, origin.ToDirection(behind), true},
+				{"cone ahead", Rule{Selectable: Selectable{Type: SelectCone, MinRange: 1, MaxRange: 3}}, move(origin, ahead, 3), true},
+				{"cone edge", Rule{Selectable: Selectable{Type: SelectCone, MinRange: 1, MaxRange: 3}}, move(origin, right, 2), true},
+				{"cone behind", Rule{Selectable: Selectable{Type: SelectCone, MinRange: 1, MaxRange: 3}}, move(origin, behind, 1), false},
+				{"cone directed behind", Rule{Selectable: Selectable{Type: SelectCone, Direction: geom.Behind, MinRange: 1, MaxRange: 3}}, move(origin, behind, 1), true},
+			} {
+				t.Run(fmt.Sprintf("%v-%v-%s", origin, facing, tc.name), func(t *testing.T) {
+					tc.rule.Brush.Type = SingleHex
+					got, _ := tc.rule.Execute(tc.selected, origin, facing)
+					if got != tc.want {
+						t.Errorf("want %t, got %t", tc.want, got)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestExecuteBrush(t *testing.T) {
+	for _, origin := range origins {
+		for _, dir := range geom.DirectionTypeValues() {
+			selected := origin.ToDirection(dir)
+			left := geom.Actualize(dir, geom.ForwardLeft)
+			right := geom.Actualize(dir, geom.ForwardRight)
+
+			t.Run(fmt.Sprintf("%v-%v", origin, dir), func(t *testing.T) {
+				// Brushes are oriented by the selection, not the facing of the
+				// user.
+				sweep := Rule{
+					Selectable: Selectable{Type: SelectWithin, MinRange: 1, MaxRange: 1},
+					Brush:      Brush{Type: Arc, ArcRadius: 1, ArcBegin: -1, ArcLength: 3},
+				}
+				ok, paints := sweep.Execute(selected, origin, geom.Opposite[dir])
+				if !ok {
+					t.Errorf("sweep: want selectable")
+				}
+				want := []geom.Key{origin.ToDirection(left), selected, origin.ToDirection(right)}
+				if fmt.Sprint(paints) != fmt.Sprint(want) {
+					t.Errorf("sweep: want %v, got %v", want, paints)
+				}
+
+				breath := Rule{
+					Selectable: Selectable{Type: SelectWithin, MinRange: 1, MaxRange: 1},
+					Brush:      Brush{Type: Cone, MinRange: 1, MaxRange: 3},
+				}
+				_, paints = breath.Execute(selected, origin, dir)
+				if len(paints) != 15 {
+					t.Errorf("cone: want 15 keys, got %d", len(paints))
+				}
+				if !contains(paints, move(origin, dir, 3)) {
+					t.Errorf("cone: want %v painted", move(origin, dir, 3))
+				}
+			})
+		}
+	}
+}
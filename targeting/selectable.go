@@ -1,5 +1,7 @@
 package targeting
 
+import "github.com/griffithsh/squads/geom"
+
 // SelectableType enumerates the styles of selection permissions.
 type SelectableType int
 
@@ -14,8 +16,21 @@ const (
 	// not less than MinRange and does not exceed MaxRange.
 	SelectWithin
 
-	// SelectLinear allows selections that are in a straight line with the origin.
-	// TODO: implement SelectLinear
+	// SelectLinear allows selections that are in a straight line from the
+	// origin, and whose distance from the origin is not less than MinRange and
+	// does not exceed MaxRange. The line is oriented by Direction relative to
+	// the facing of the user.
+	SelectLinear
+
+	// SelectArc allows selections on an arc of the ring that is ArcRadius
+	// hexes from the origin. The arc is oriented by Direction relative to the
+	// facing of the user.
+	SelectArc
+
+	// SelectCone allows selections within a 120 degree cone that spreads out
+	// from the origin, between MinRange and MaxRange hexes away. The cone is
+	// oriented by Direction relative to the facing of the user.
+	SelectCone
 
 	// Untargeted skills cannot be targeted on any specific hex.
 	Untargeted
@@ -29,6 +44,16 @@ type Selectable struct {
 	MinRange int
 	MaxRange int
 
-	// LinearLength    int
-	// LinearDirection geom.RelativeDirection
+	// Direction orients SelectLinear, SelectArc and SelectCone relative to
+	// the facing of the user.
+	Direction geom.RelativeDirection
+
+	// Only for SelectArc.
+	ArcRadius int
+	ArcBegin  int
+	ArcLength int
+
+	// Filters restrict selections to hexes that match at least one of the
+	// TargetFilters. When there are no Filters, any hex can be selected.
+	Filters []TargetFilter
 }
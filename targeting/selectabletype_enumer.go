@@ -8,11 +8,11 @@ import (
 	"strings"
 )
 
-const _SelectableTypeName = "SelectAnywhereSelectWithinUntargeted"
+const _SelectableTypeName = "SelectAnywhereSelectWithinSelectLinearSelectArcSelectConeUntargeted"
 
-var _SelectableTypeIndex = [...]uint8{0, 14, 26, 36}
+var _SelectableTypeIndex = [...]uint8{0, 14, 26, 38, 47, 57, 67}
 
-const _SelectableTypeLowerName = "selectanywhereselectwithinuntargeted"
+const _SelectableTypeLowerName = "selectanywhereselectwithinselectlinearselectarcselectconeuntargeted"
 
 func (i SelectableType) String() string {
 	if i < 0 || i >= SelectableType(len(_SelectableTypeIndex)-1) {
@@ -27,24 +27,36 @@ func _SelectableTypeNoOp() {
 	var x [1]struct{}
 	_ = x[SelectAnywhere-(0)]
 	_ = x[SelectWithin-(1)]
-	_ = x[Untargeted-(2)]
+	_ = x[SelectLinear-(2)]
+	_ = x[SelectArc-(3)]
+	_ = x[SelectCone-(4)]
+	_ = x[Untargeted-(5)]
 }
 
-var _SelectableTypeValues = []SelectableType{SelectAnywhere, SelectWithin, Untargeted}
+var _SelectableTypeValues = []SelectableType{SelectAnywhere, SelectWithin, SelectLinear, SelectArc, SelectCone, Untargeted}
 
 var _SelectableTypeNameToValueMap = map[string]SelectableType{
 	_SelectableTypeName[0:14]:       SelectAnywhere,
 	_SelectableTypeLowerName[0:14]:  SelectAnywhere,
 	_SelectableTypeName[14:26]:      SelectWithin,
 	_SelectableTypeLowerName[14:26]: SelectWithin,
-	_SelectableTypeName[26:36]:      Untargeted,
-	_SelectableTypeLowerName[26:36]: Untargeted,
+	_SelectableTypeName[26:38]:      SelectLinear,
+	_SelectableTypeLowerName[26:38]: SelectLinear,
+	_SelectableTypeName[38:47]:      SelectArc,
+	_SelectableTypeLowerName[38:47]: SelectArc,
+	_SelectableTypeName[47:57]:      SelectCone,
+	_SelectableTypeLowerName[47:57]: SelectCone,
+	_SelectableTypeName[57:67]:      Untargeted,
+	_SelectableTypeLowerName[57:67]: Untargeted,
 }
 
 var _SelectableTypeNames = []string{
 	_SelectableTypeName[0:14],
 	_SelectableTypeName[14:26],
-	_SelectableTypeName[26:36],
+	_SelectableTypeName[26:38],
+	_SelectableTypeName[38:47],
+	_SelectableTypeName[47:57],
+	_SelectableTypeName[57:67],
 }
 
 // SelectableTypeString retrieves an enum value from the enum constants string name.
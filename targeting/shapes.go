@@ -0,0 +1,81 @@
+package targeting
+
+import "github.com/griffithsh/squads/geom"
+
+// ring returns the Keys that are radius hexes from origin, in clockwise order,
+// beginning with the Key that is in the start direction from origin.
+func ring(origin geom.Key, radius int, start geom.DirectionType) []geom.Key {
+	cubes := geom.Ring(origin.Cube(), radius)
+
+	// geom.Ring begins from N, so skip ahead by a side of the ring for every
+	// direction that start is clockwise of N.
+	offset := (int(start) * radius) % len(cubes)
+	result := make([]geom.Key, len(cubes))
+	for i := range cubes {
+		result[i] = cubes[(i+offset)%len(cubes)].Key()
+	}
+	return result
+}
+
+// arc returns length Keys from the ring that is radius hexes from origin,
+// beginning begin hexes clockwise of the Key in the toward direction. A
+// negative length walks anticlockwise instead.
+func arc(origin geom.Key, radius int, toward geom.DirectionType, begin, length int) []geom.Key {
+	r := ring(origin, radius, toward)
+	n := len(r)
+
+	step := 1
+	if length < 0 {
+		step = -1
+		length = -length
+	}
+	if length > n {
+		length = n
+	}
+
+	result := make([]geom.Key, 0, length)
+	for i := 0; i < length; i++ {
+		j := ((begin+i*step)%n + n) % n
+		result = append(result, r[j])
+	}
+	return result
+}
+
+// cone returns the Keys within a 120 degree cone from origin in the toward
+// direction, that are between min and max hexes from origin.
+func cone(origin geom.Key, toward geom.DirectionType, min, max int) []geom.Key {
+	result := []geom.Key{}
+	for d := min; d <= max; d++ {
+		if d <= 0 {
+			result = append(result, origin)
+			continue
+		}
+		result = append(result, arc(origin, d, toward, -d, 2*d+1)...)
+	}
+	return result
+}
+
+// linear returns whether other is in a straight line from origin, and if so,
+// the direction of that line.
+func linear(origin, other geom.Key) (geom.DirectionType, bool) {
+	d := other.Cube().Sub(origin.Cube())
+	distance := origin.HexesFrom(other)
+	if distance == 0 {
+		return geom.N, false
+	}
+	for _, dir := range geom.DirectionTypeValues() {
+		if geom.CubeDirection(dir).Scale(distance) == d {
+			return dir, true
+		}
+	}
+	return geom.N, false
+}
+
+func contains(keys []geom.Key, k geom.Key) bool {
+	for _, key := range keys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
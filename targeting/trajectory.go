@@ -0,0 +1,22 @@
+package targeting
+
+// Trajectory enumerates the ways that a skill travels from its user to the
+// selected hex, and so which obstacles can block it.
+type Trajectory int
+
+//go:generate go run github.com/dmarkham/enumer -type=Trajectory -json
+
+const (
+	// Direct skills need a clear line of sight to their target, and are
+	// blocked by terrain and by other characters. Skills are Direct unless
+	// they say otherwise.
+	Direct Trajectory = iota
+
+	// Arcing skills travel over obstacles and do not need line of sight to
+	// their target. Spells and lobbed projectiles are Arcing.
+	Arcing
+
+	// Piercing skills pass through characters, but are still blocked by
+	// terrain.
+	Piercing
+)
@@ -75,6 +75,12 @@ func randomCharData(archive *data.Archive) embark.CharacterSheetData {
 		OverlayFrameY: overlayBig.Y,
 		Prep:          91,
 		AP:            202,
+		HP:            48,
+		EN:            26,
+		Str:           1,
+		Agi:           0,
+		Int:           0,
+		Vit:           2,
 		Strlvl:        fmt.Sprintf("%.2f", 1.2),
 		Agilvl:        fmt.Sprintf("%.2f", 0.876),
 		Intlvl:        fmt.Sprintf("%.2f", 0.9012),
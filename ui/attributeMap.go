@@ -235,7 +235,7 @@ var permittedAttributes = map[ElementType][]string{
 	ColumnElement:  {"twelfths", "align"},
 	TextElement:    {"value", "size", "layout", "color", "width"},
 	ButtonElement:  {"onclick", "label", "width", "id"},
-	ImageElement:   {"texture", "width", "height", "x", "y", "intangible", "onclick", "id"},
+	ImageElement:   {"texture", "width", "height", "x", "y", "intangible", "onclick", "onhover", "id"},
 	IfElement:      {"expr"},
 	RangeElement:   {"over"},
 }
@@ -16,6 +16,16 @@ func (UIInteract) Type() event.Type {
 	return "ui.UIInteract"
 }
 
+// UIHover happens when the player moves the mouse.
+type UIHover struct {
+	AbsoluteX, AbsoluteY float64
+}
+
+// Type of the Event.
+func (UIHover) Type() event.Type {
+	return "ui.UIHover"
+}
+
 // Interact happens when a UIInteract event is unhandled by any UI.
 type Interact struct {
 	X, Y                 float64
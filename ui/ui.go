@@ -19,6 +19,13 @@ type InteractiveRegion struct {
 	Handler func()
 }
 
+// hoverRegion is an area of a UI that reports when the mouse is over it.
+type hoverRegion struct {
+	Bounds  image.Rectangle
+	ID      string
+	Handler func(id string)
+}
+
 type RenderInstruction interface {
 }
 
@@ -50,6 +57,12 @@ type UI struct {
 
 	interactives       []InteractiveRegion
 	renderinstructions []RenderInstruction
+
+	hovers []hoverRegion
+	// hovered is the id of the hoverRegion that the mouse is over, and leave
+	// is the Handler to call when the mouse moves off it.
+	hovered string
+	leave   func(id string)
 }
 
 func (c *UI) RenderInstructions() []RenderInstruction {
@@ -112,6 +125,10 @@ func NewUISystem(mgr *ecs.World, bus *event.Bus) *UISystem {
 		ev := t.(*UIInteract)
 		uis.Handle(ev)
 	})
+	bus.Subscribe(UIHover{}.Type(), func(t event.Typer) {
+		ev := t.(*UIHover)
+		uis.Hover(ev)
+	})
 	bus.Subscribe(game.WindowSizeChanged{}.Type(), func(e event.Typer) {
 		wsc := e.(*game.WindowSizeChanged)
 		uis.screenW, uis.screenH = wsc.NewW, wsc.NewH
@@ -128,6 +145,7 @@ func (sys *UISystem) Update(elapsed time.Duration) error {
 
 		uic.interactives = uic.interactives[:0]
 		uic.renderinstructions = uic.renderinstructions[:0]
+		uic.hovers = uic.hovers[:0]
 
 		// decrement all delays, and execute ripe ones
 		sys.delays = slices.DeleteFunc(sys.delays, func(d *delay) bool {
@@ -174,6 +192,36 @@ func (uis *UISystem) Handle(ev *UIInteract) {
 	uis.bus.Publish(&Interact{X: ev.X, Y: ev.Y, AbsoluteX: ev.AbsoluteX, AbsoluteY: ev.AbsoluteY})
 }
 
+// Hover calls the onhover handler of the element that the mouse has moved
+// onto with its id, or the handler of the element it has moved off with an
+// empty id.
+func (uis *UISystem) Hover(ev *UIHover) {
+	uiScale := 2.0 // FIXME this needs to come from somewhere ...
+
+	hoverPoint := image.Point{int(ev.AbsoluteX / uiScale), int(ev.AbsoluteY / uiScale)}
+	for _, e := range uis.mgr.Get([]string{"UI"}) {
+		uic := uis.mgr.Component(e, "UI").(*UI)
+
+		var over *hoverRegion
+		for i, hover := range uic.hovers {
+			if hoverPoint.In(hover.Bounds) {
+				over = &uic.hovers[i]
+				break
+			}
+		}
+
+		switch {
+		case over != nil && over.ID != uic.hovered:
+			uic.hovered, uic.leave = over.ID, over.Handler
+			over.Handler(over.ID)
+		case over == nil && uic.leave != nil:
+			leave := uic.leave
+			uic.hovered, uic.leave = "", nil
+			leave("")
+		}
+	}
+}
+
 // realiseChildren replaces IfElements and RangeElements with their children as
 // the data dictates.  Also returns the datas that should be applied per child -
 // remember RangeElements are bound to alternative data sources.
@@ -409,6 +457,22 @@ func calculateChildren(root *UI, children []*Element, data interface{}, availabl
 				})
 			}
 
+			if onhover := child.Attributes["onhover"]; onhover != "" {
+				id, err := Resolve(child.Attributes["id"], data)
+				if err != nil {
+					return fmt.Errorf("Resolve %s: %v", child.Attributes["id"], err)
+				}
+				root.hovers = append(root.hovers, hoverRegion{
+					Bounds: image.Rect(x, y, x+width, y+height),
+					ID:     id,
+					Handler: func(id string) {
+						if err := dynamic.Call(onhover, data, id); err != nil {
+							panic(fmt.Sprintf("dynamic call: %v", err))
+						}
+					},
+				})
+			}
+
 		case TextElement:
 			label := child.Attributes["value"]
 			sz := child.Attributes.FontSize()